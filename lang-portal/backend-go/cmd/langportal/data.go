@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/backup"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db/seeds"
+)
+
+// runSeed fills the database with the seed pack, or the seed file if one is
+// configured. A database that already has words is only replaced with -reset.
+func runSeed(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("seed")
+	reset := fs.Bool("reset", false, "replace the words, groups and study history already in the database")
+	cfg, err := e.load(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("seed takes no arguments")
+	}
+	seed, err := seedScript(cfg)
+	if err != nil {
+		return err
+	}
+
+	st, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer st.Close()
+
+	stats, err := st.QuickStats(ctx)
+	if err != nil {
+		return err
+	}
+	if stats.TotalWords > 0 && !*reset {
+		return fmt.Errorf("database already has %d words; use -reset to replace them", stats.TotalWords)
+	}
+	// A full reset also restarts IDs, which the seed packs rely on
+	if err := st.FullReset(ctx, seed); err != nil {
+		return err
+	}
+
+	switch {
+	case cfg.SeedFile != "":
+		fmt.Fprintf(e.stdout, "Seeded database from %s\n", cfg.SeedFile)
+	case cfg.SeedPack == seeds.None:
+		fmt.Fprintln(e.stdout, "Emptied database")
+	default:
+		fmt.Fprintf(e.stdout, "Seeded database with the %s seed pack\n", cfg.SeedPack)
+	}
+	return nil
+}
+
+// runResetHistory deletes all study sessions and word reviews
+func runResetHistory(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("reset-history")
+	cfg, err := e.load(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("reset-history takes no arguments")
+	}
+
+	st, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer st.Close()
+
+	if err := st.ResetHistory(ctx); err != nil {
+		return err
+	}
+	fmt.Fprintln(e.stdout, "Deleted all study sessions and word reviews")
+	return nil
+}
+
+// runStats prints the dashboard's quick statistics
+func runStats(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("stats")
+	cfg, err := e.load(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("stats takes no arguments")
+	}
+
+	st, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer st.Close()
+
+	stats, err := st.QuickStats(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "Words:           %d\n", stats.TotalWords)
+	fmt.Fprintf(e.stdout, "Words studied:   %d\n", stats.WordsStudied)
+	fmt.Fprintf(e.stdout, "Study sessions:  %d\n", stats.TotalSessions)
+	fmt.Fprintf(e.stdout, "Reviews:         %d\n", stats.TotalReviews)
+	fmt.Fprintf(e.stdout, "Average mastery: %.0f%%\n", stats.AverageMastery*100)
+	return nil
+}
+
+// runBackup backs up a SQLite database to the given file, or to a new file in
+// the backup directory, pruning old backups as the server does
+func runBackup(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("backup")
+	cfg, err := e.load(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return usageErrorf("backup takes at most one file")
+	}
+
+	st, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer st.Close()
+
+	backups := backup.NewDir(cfg.BackupDir, cfg.BackupRetention)
+	path := fs.Arg(0)
+	if path == "" {
+		if path, err = backups.NewPath(time.Now()); err != nil {
+			return err
+		}
+	}
+	if err := st.Backup(ctx, path); err != nil {
+		if fs.NArg() == 0 {
+			os.Remove(path)
+		}
+		return err
+	}
+	if fs.NArg() == 0 {
+		if err := backups.Prune(); err != nil {
+			return fmt.Errorf("failed to prune backups: %v", err)
+		}
+	}
+	fmt.Fprintf(e.stdout, "Backed up database to %s\n", path)
+	return nil
+}
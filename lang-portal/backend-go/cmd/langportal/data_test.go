@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSeed(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+
+	// Test seeding an empty database
+	out, err := langportal(t, dir, "", "seed", "-seed-pack", "travel")
+	if err != nil {
+		t.Fatalf("Failed to seed: %v", err)
+	}
+	if !strings.Contains(out, "travel seed pack") {
+		t.Errorf("Expected the travel seed pack, got %q", out)
+	}
+
+	// Test a seeded database is only replaced with -reset
+	if _, err := langportal(t, dir, "", "seed"); err == nil || !strings.Contains(err.Error(), "-reset") {
+		t.Errorf("Expected seeding a full database to need -reset, got %v", err)
+	}
+	if _, err := langportal(t, dir, "", "seed", "-reset"); err != nil {
+		t.Fatalf("Failed to reseed: %v", err)
+	}
+	out, err = langportal(t, dir, "", "stats")
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if !strings.Contains(out, "Words:           15") {
+		t.Errorf("Expected the 15 words of the default seed pack, got %q", out)
+	}
+}
+
+func TestResetHistoryAndStats(t *testing.T) {
+	// Setup a database with a study session and a review
+	dir := t.TempDir()
+	if _, err := langportal(t, dir, "", "seed"); err != nil {
+		t.Fatalf("Failed to seed: %v", err)
+	}
+	st, err := openStore(loadTestConfig(t, dir))
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	if _, err := st.RecordReview(context.Background(), 1, 1, true, 1.0); err != nil {
+		t.Fatalf("Failed to record review: %v", err)
+	}
+	st.Close()
+
+	// Test stats count the review
+	out, err := langportal(t, dir, "", "stats")
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if !strings.Contains(out, "Reviews:         1") || !strings.Contains(out, "Average mastery: 100%") {
+		t.Errorf("Expected 1 correct review, got %q", out)
+	}
+
+	// Test resetting the history clears it
+	if _, err := langportal(t, dir, "", "reset-history"); err != nil {
+		t.Fatalf("Failed to reset history: %v", err)
+	}
+	out, err = langportal(t, dir, "", "stats")
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if !strings.Contains(out, "Reviews:         0") || !strings.Contains(out, "Words:           15") {
+		t.Errorf("Expected no reviews and all words kept, got %q", out)
+	}
+}
+
+func TestBackup(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+	if _, err := langportal(t, dir, "", "seed"); err != nil {
+		t.Fatalf("Failed to seed: %v", err)
+	}
+
+	// Test backing up to the backup directory and to a named file
+	if _, err := langportal(t, dir, "", "backup"); err != nil {
+		t.Fatalf("Failed to back up: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "backups"))
+	if err != nil || len(entries) != 1 {
+		t.Errorf("Expected 1 backup in the backup directory, got %d (%v)", len(entries), err)
+	}
+	path := filepath.Join(dir, "copy.db")
+	out, err := langportal(t, dir, "", "backup", path)
+	if err != nil {
+		t.Fatalf("Failed to back up to a file: %v", err)
+	}
+	if !strings.Contains(out, path) {
+		t.Errorf("Expected the backup path, got %q", out)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected a backup at %s: %v", path, err)
+	}
+}
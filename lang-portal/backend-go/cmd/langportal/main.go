@@ -0,0 +1,183 @@
+// Command langportal runs the language portal server and manages its
+// database. Run it without arguments for a list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/config"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db/migrations"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db/seeds"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
+)
+
+// command is a langportal subcommand. Its run function receives the
+// arguments after the command name: flags, then any operands.
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, e *env, args []string) error
+}
+
+var commands = []command{
+	{"serve", "", "run the HTTP server, creating the database on first start", runServe},
+	{"migrate", "up|down|status", "apply, roll back the latest, or list migrations", runMigrate},
+	{"seed", "", "fill the database with the configured seed pack", runSeed},
+	{"reset-history", "", "delete all study sessions and word reviews", runResetHistory},
+	{"import", "FILE", "import words from an export file, or - for stdin", runImport},
+	{"export", "[FILE]", "export words with their groups and tags, to stdout by default", runExport},
+	{"stats", "", "print study statistics", runStats},
+	{"backup", "[FILE]", "back up a SQLite database, to the backup directory by default", runBackup},
+}
+
+// env is what commands read from and write to
+type env struct {
+	getenv func(string) string
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// usageError reports that langportal was invoked incorrectly
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usageErrorf(format string, args ...interface{}) error {
+	return &usageError{fmt.Sprintf(format, args...)}
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	e := &env{getenv: os.Getenv, stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	err := run(ctx, os.Args[1:], e)
+	stop()
+
+	var usage *usageError
+	switch {
+	case err == nil || errors.Is(err, flag.ErrHelp):
+	case errors.As(err, &usage):
+		fmt.Fprintf(e.stderr, "Error: %v\n", err)
+		os.Exit(2)
+	default:
+		fmt.Fprintf(e.stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command named by the first argument
+func run(ctx context.Context, args []string, e *env) error {
+	if len(args) == 0 {
+		printUsage(e.stderr)
+		return usageErrorf("no command given")
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		printUsage(e.stdout)
+		return nil
+	}
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(ctx, e, args[1:])
+		}
+	}
+	printUsage(e.stderr)
+	return usageErrorf("unknown command %q", args[0])
+}
+
+// printUsage lists the commands
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: langportal <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-28s %s\n", cmd.name+" "+cmd.args, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Every command accepts the settings flags; run "langportal <command> -h" to list them.`)
+}
+
+// flags returns the flag set of a command
+func (e *env) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("langportal "+name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	return fs
+}
+
+// load parses a command's flags along with the settings flags
+func (e *env) load(fs *flag.FlagSet, args []string) (*config.Config, error) {
+	cfg, err := config.LoadFlags(fs, args, e.getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, &usageError{err.Error()}
+	}
+	return cfg, nil
+}
+
+// connect opens the configured database the way the server does
+func connect(cfg *config.Config) (*db.Conn, error) {
+	conn, err := db.Connect(cfg.DBPath, db.Options{
+		JournalMode:  cfg.SQLiteJournalMode,
+		Synchronous:  cfg.SQLiteSynchronous,
+		BusyTimeout:  time.Duration(cfg.SQLiteBusyTimeout),
+		MaxOpenConns: cfg.DBMaxOpenConns,
+		MaxIdleConns: cfg.DBMaxIdleConns,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %v", err)
+	}
+	return conn, nil
+}
+
+// openStore connects to the configured database and applies pending
+// migrations. Unlike serve, it leaves new databases empty.
+func openStore(cfg *config.Config) (*store.SQL, error) {
+	conn, err := connect(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Migrate(conn.DB(), conn.Dialect(), migrationsFS(cfg), ""); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return store.New(conn, media.Dir(cfg.MediaDir)), nil
+}
+
+// migrationsFS returns the embedded migrations, or the configured migrations
+// directory
+func migrationsFS(cfg *config.Config) fs.FS {
+	if cfg.MigrationsDir != "" {
+		return os.DirFS(cfg.MigrationsDir)
+	}
+	return migrations.FS
+}
+
+// seedScript returns the SQL databases are seeded with: the configured seed
+// file, or else the configured seed pack
+func seedScript(cfg *config.Config) (string, error) {
+	if cfg.SeedFile != "" {
+		contents, err := os.ReadFile(cfg.SeedFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read seed file: %v", err)
+		}
+		return string(contents), nil
+	}
+	return seeds.Load(cfg.SeedPack)
+}
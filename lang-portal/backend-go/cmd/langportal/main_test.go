@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/config"
+)
+
+// newTestEnv returns an env whose database, media and backups are kept in
+// dir, and the buffer it writes stdout to
+func newTestEnv(dir, stdin string) (*env, *bytes.Buffer) {
+	settings := map[string]string{
+		"LANGPORTAL_DB_PATH":    filepath.Join(dir, "words.db"),
+		"LANGPORTAL_MEDIA_DIR":  filepath.Join(dir, "media"),
+		"LANGPORTAL_BACKUP_DIR": filepath.Join(dir, "backups"),
+		"LANGPORTAL_GIN_MODE":   "test",
+		"LANGPORTAL_LOG_LEVEL":  "warn",
+	}
+	var stdout bytes.Buffer
+	return &env{
+		getenv: func(key string) string { return settings[key] },
+		stdin:  strings.NewReader(stdin),
+		stdout: &stdout,
+		stderr: io.Discard,
+	}, &stdout
+}
+
+// langportal runs the command line against dir and returns its output
+func langportal(t *testing.T, dir, stdin string, args ...string) (string, error) {
+	t.Helper()
+	e, stdout := newTestEnv(dir, stdin)
+	err := run(context.Background(), args, e)
+	return stdout.String(), err
+}
+
+func TestRunUsage(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+
+	// Test help lists every command
+	out, err := langportal(t, dir, "", "help")
+	if err != nil {
+		t.Fatalf("Failed to print help: %v", err)
+	}
+	for _, cmd := range commands {
+		if !strings.Contains(out, cmd.name) {
+			t.Errorf("Expected help to list %s, got %q", cmd.name, out)
+		}
+	}
+
+	// Test invocation mistakes are usage errors
+	tests := [][]string{
+		nil,
+		{"frobnicate"},
+		{"migrate"},
+		{"migrate", "sideways"},
+		{"stats", "-port", "1"},
+		{"import"},
+		{"serve", "extra"},
+	}
+	for _, args := range tests {
+		var usage *usageError
+		if _, err := langportal(t, dir, "", args...); !errors.As(err, &usage) {
+			t.Errorf("%v: expected a usage error, got %v", args, err)
+		}
+	}
+}
+
+// loadTestConfig returns the configuration commands run with in dir
+func loadTestConfig(t *testing.T, dir string) *config.Config {
+	t.Helper()
+	e, _ := newTestEnv(dir, "")
+	cfg, err := e.load(e.flags("test"), nil)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	return cfg
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+)
+
+// runMigrate applies pending migrations, rolls back the latest one, or lists
+// them. Unlike serve, migrate up does not seed new databases.
+func runMigrate(ctx context.Context, e *env, args []string) error {
+	if len(args) == 0 {
+		return usageErrorf("migrate needs up, down or status")
+	}
+	action := args[0]
+	if action != "up" && action != "down" && action != "status" {
+		return usageErrorf("unknown migrate action %q: use up, down or status", action)
+	}
+
+	fs := e.flags("migrate " + action)
+	cfg, err := e.load(fs, args[1:])
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("migrate %s takes no arguments", action)
+	}
+	conn, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	switch action {
+	case "up":
+		if _, err := db.Migrate(conn.DB(), conn.Dialect(), migrationsFS(cfg), ""); err != nil {
+			return err
+		}
+		version, err := db.SchemaVersion(ctx, conn.DB(), conn.Dialect())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stdout, "Database is at version %d\n", version)
+	case "down":
+		version, err := db.Rollback(conn.DB(), conn.Dialect(), migrationsFS(cfg))
+		if err != nil {
+			return err
+		}
+		if version == 0 {
+			fmt.Fprintln(e.stdout, "No migrations to roll back")
+		} else {
+			fmt.Fprintf(e.stdout, "Rolled back migration %d\n", version)
+		}
+	case "status":
+		migrations, err := db.MigrationStatus(conn.DB(), conn.Dialect(), migrationsFS(cfg))
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tMIGRATION\tAPPLIED")
+		for _, m := range migrations {
+			applied := "pending"
+			if m.AppliedAt != nil {
+				applied = m.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", m.Version, m.Name, applied)
+		}
+		return w.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+
+	// Test status before any migration
+	out, err := langportal(t, dir, "", "migrate", "status")
+	if err != nil {
+		t.Fatalf("Failed to get migration status: %v", err)
+	}
+	if strings.Count(out, "pending") != 5 {
+		t.Errorf("Expected 5 pending migrations, got %q", out)
+	}
+
+	// Test migrating up and rolling back the latest migration
+	out, err = langportal(t, dir, "", "migrate", "up")
+	if err != nil {
+		t.Fatalf("Failed to migrate up: %v", err)
+	}
+	if !strings.Contains(out, "version 5") {
+		t.Errorf("Expected version 5, got %q", out)
+	}
+	out, err = langportal(t, dir, "", "migrate", "down")
+	if err != nil {
+		t.Fatalf("Failed to migrate down: %v", err)
+	}
+	if !strings.Contains(out, "Rolled back migration 5") {
+		t.Errorf("Expected migration 5 to be rolled back, got %q", out)
+	}
+	out, err = langportal(t, dir, "", "migrate", "status")
+	if err != nil {
+		t.Fatalf("Failed to get migration status: %v", err)
+	}
+	if strings.Count(out, "pending") != 1 || !strings.Contains(out, "005_enforce_foreign_keys.up.sql") {
+		t.Errorf("Expected only migration 5 pending, got %q", out)
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/api"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/config"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/health"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/limits"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/logging"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/metrics"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/requestid"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/server"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/tracing"
+	"github.com/gin-gonic/gin"
+)
+
+// readyTimeout bounds each readiness check, so a hung database fails the
+// probe rather than stalling it
+const readyTimeout = 2 * time.Second
+
+// traceFlushTimeout bounds sending the remaining spans on shutdown
+const traceFlushTimeout = 5 * time.Second
+
+// runServe serves the API until ctx is done. A missing or empty database is
+// created and seeded first, and pending migrations are applied.
+func runServe(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("serve")
+	cfg, err := e.load(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("serve takes no arguments")
+	}
+	gin.SetMode(cfg.GinMode)
+	logger := logging.New(e.stdout, cfg.LogFormat, cfg.LogLevel)
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	defer slog.SetDefault(previous)
+
+	// Export traces when an exporter is configured, flushing them on exit
+	shutdownTracing, err := tracing.Setup(ctx, cfg.TraceExporter, cfg.TraceEndpoint, e.stdout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error("Failed to flush traces", "err", err)
+		}
+	}()
+
+	// Initialize database connection
+	conn, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Create and seed a new database, or bring an existing one up to date
+	seed, err := seedScript(cfg)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	created, err := db.Migrate(conn.DB(), conn.Dialect(), migrationsFS(cfg), seed)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to migrate database: %v", err)
+	}
+	if created {
+		logger.Info("Created new database", "db_path", cfg.Redacted().DBPath)
+	}
+	st := store.New(conn, media.Dir(cfg.MediaDir))
+	for name, pool := range conn.Pools() {
+		metrics.RegisterDB(name, pool)
+	}
+
+	// Create Gin router, tracing and logging every request under its request ID
+	r := gin.New()
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		st.Close()
+		return fmt.Errorf("invalid trusted proxies: %v", err)
+	}
+	r.Use(requestid.Middleware())
+	r.Use(tracing.Middleware())
+	r.Use(logging.Middleware(logger))
+	r.Use(metrics.Middleware())
+	r.Use(gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+		logger.ErrorContext(c.Request.Context(), "Recovered from panic", "panic", err, "stack", string(debug.Stack()))
+		problem.Abort(c, problem.Internal, "Unexpected error")
+	}))
+
+	// Setup CORS
+	r.Use(corsMiddleware(cfg.CORSOrigins))
+
+	// Setup rate and body size limited routes, with metrics and probes served
+	// outside the API
+	checker, err := readinessChecks(cfg, conn)
+	if err != nil {
+		st.Close()
+		return err
+	}
+	limiter := limits.NewRateLimiter(limits.Rates{
+		Read:  cfg.RateLimitRead,
+		Write: cfg.RateLimitWrite,
+		Admin: cfg.RateLimitAdmin,
+	}, cfg.APIKeys, api.IsAdminPath)
+	api.NewServer(st, cfg).RegisterRoutes(r.Group("", limiter.Middleware(), limits.BodySize(int64(cfg.MaxBodyBytes), api.MaxUploadBytes)))
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+	r.GET("/healthz", health.Live)
+	r.GET("/readyz", checker.Ready)
+
+	// Serve, draining in-flight requests once ctx is done
+	logger.Info("Server starting", "addr", cfg.ListenAddr)
+	srv := server.New(cfg.ListenAddr, r, time.Duration(cfg.DrainTimeout))
+	serveErr := srv.ListenAndServe(ctx)
+	if serveErr == nil {
+		logger.Info("Server stopped, closing database")
+	}
+
+	// Flush the WAL and close the database before exiting
+	if err := st.Close(); err != nil {
+		logger.Error("Failed to close database", "err", err)
+	}
+	if serveErr != nil {
+		return fmt.Errorf("server error: %v", serveErr)
+	}
+	return nil
+}
+
+// corsMiddleware allows cross-origin requests from the configured origins
+func corsMiddleware(origins []string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		allowed[strings.TrimSuffix(origin, "/")] = true
+	}
+
+	return func(c *gin.Context) {
+		if allowed["*"] {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		} else if origin := c.GetHeader("Origin"); allowed[origin] {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			c.Writer.Header().Add("Vary", "Origin")
+		}
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+limits.KeyHeader+", traceparent, tracestate, "+requestid.Header)
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "Retry-After, "+requestid.Header)
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+		c.Next()
+	}
+}
+
+// readinessChecks returns the checks behind /readyz: the database answers,
+// its schema is at the latest migration, and the disks holding the database
+// and media have space left
+func readinessChecks(cfg *config.Config, conn *db.Conn) (*health.Checker, error) {
+	expected, err := db.LatestVersion(conn.Dialect().Migrations(migrationsFS(cfg)))
+	if err != nil {
+		return nil, err
+	}
+	minFree := uint64(cfg.MinFreeSpaceMB) << 20
+
+	checker := health.NewChecker(readyTimeout)
+	checker.Add("database", health.Ping(conn.Pools()))
+	checker.Add("migrations", health.SchemaVersion(conn.Reader(), conn.Dialect(), expected))
+	if path, ok := db.FilePath(cfg.DBPath); ok {
+		checker.Add("database_disk", health.DiskSpace(filepath.Dir(path), minFree))
+	}
+	checker.Add("media_disk", health.DiskSpace(cfg.MediaDir, minFree))
+	return checker, nil
+}
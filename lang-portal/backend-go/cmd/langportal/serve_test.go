@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServe(t *testing.T) {
+	// Setup a free port and an empty directory
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to find a free port: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	dir := t.TempDir()
+
+	e, stdout := newTestEnv(dir, "")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, []string{"serve", "-listen-addr", addr, "-log-level", "info"}, e)
+	}()
+
+	// Test the server creates and seeds the database on first start
+	var body string
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := http.Get("http://" + addr + "/api/groups")
+		if err == nil {
+			data, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			body = string(data)
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if !strings.Contains(body, "Basic Greetings") {
+		t.Errorf("Expected the seeded groups, got %q", body)
+	}
+
+	// Test metrics are served, labelled by route
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatalf("Failed to fetch metrics: %v", err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	for _, want := range []string{
+		`langportal_http_requests_total{method="GET",route="/api/groups",status="200"}`,
+		`langportal_store_query_duration_seconds_count{method="ListGroups"}`,
+		`go_sql_open_connections{db_name="read"}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected metrics to include %s", want)
+		}
+	}
+
+	// Test the probes report a fully migrated database
+	for _, probe := range []string{"/healthz", "/readyz"} {
+		resp, err := http.Get("http://" + addr + probe)
+		if err != nil {
+			t.Fatalf("Failed to fetch %s: %v", probe, err)
+		}
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.HasPrefix(string(data), `{"status":"ok"`) {
+			t.Errorf("Expected %s to be ok, got %d %s", probe, resp.StatusCode, data)
+		}
+		if probe == "/readyz" && !strings.Contains(string(data), `"migrations"`) {
+			t.Errorf("Expected the migration check to be reported, got %s", data)
+		}
+	}
+
+	// Test the server stops cleanly
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Expected serve to stop cleanly, got %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Created new database") {
+		t.Errorf("Expected the new database to be reported, got %q", stdout.String())
+	}
+
+	// Test requests are logged with their ID
+	if !strings.Contains(stdout.String(), "path=/api/groups status=200") || !strings.Contains(stdout.String(), "request_id=") {
+		t.Errorf("Expected the request to be logged, got %q", stdout.String())
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+)
+
+// runImport adds the words of an export file, merging them into words that
+// already exist
+func runImport(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("import")
+	cfg, err := e.load(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("import takes one file, or - for stdin")
+	}
+
+	var r io.Reader = e.stdin
+	if name := fs.Arg(0); name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	var export models.Export
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&export); err != nil {
+		return fmt.Errorf("failed to read export file: %v", err)
+	}
+
+	st, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer st.Close()
+
+	created, err := st.ImportWords(ctx, export.Words)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "Imported %d words: %d new, %d already present\n", len(export.Words), created, len(export.Words)-created)
+	return nil
+}
+
+// runExport writes every word with its groups and tags to a file, or to
+// stdout, in the format import reads
+func runExport(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("export")
+	cfg, err := e.load(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return usageErrorf("export takes at most one file")
+	}
+
+	st, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer st.Close()
+
+	words, err := st.ExportWords(ctx)
+	if err != nil {
+		return err
+	}
+
+	name := fs.Arg(0)
+	if name == "" || name == "-" {
+		return writeExport(e.stdout, words)
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := writeExport(f, words); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write export: %v", err)
+	}
+	fmt.Fprintf(e.stdout, "Exported %d words to %s\n", len(words), name)
+	return nil
+}
+
+// writeExport writes words as an indented export file
+func writeExport(w io.Writer, words []models.ExportedWord) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(models.Export{Words: words}); err != nil {
+		return fmt.Errorf("failed to write export: %v", err)
+	}
+	return nil
+}
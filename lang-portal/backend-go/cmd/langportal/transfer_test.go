@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+)
+
+func TestImportExport(t *testing.T) {
+	// Setup a seeded database and an empty one
+	from, to := t.TempDir(), t.TempDir()
+	if _, err := langportal(t, from, "", "seed"); err != nil {
+		t.Fatalf("Failed to seed: %v", err)
+	}
+
+	// Test exporting to a file
+	path := filepath.Join(from, "words.json")
+	if _, err := langportal(t, from, "", "export", path); err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+	var export models.Export
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("Failed to parse export: %v", err)
+	}
+	if len(export.Words) != 15 || export.Words[0].English != "hello" || len(export.Words[0].Groups) == 0 {
+		t.Errorf("Expected 15 words starting with hello in its groups, got %+v", export.Words)
+	}
+
+	// Test importing it from stdin into another database, then again
+	out, err := langportal(t, to, string(data), "import", "-")
+	if err != nil {
+		t.Fatalf("Failed to import: %v", err)
+	}
+	if !strings.Contains(out, "15 new") {
+		t.Errorf("Expected 15 new words, got %q", out)
+	}
+	out, err = langportal(t, to, "", "import", path)
+	if err != nil {
+		t.Fatalf("Failed to import again: %v", err)
+	}
+	if !strings.Contains(out, "0 new, 15 already present") {
+		t.Errorf("Expected every word to be present already, got %q", out)
+	}
+
+	// Test the imported database exports the same words to stdout
+	out, err = langportal(t, to, "", "export")
+	if err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+	if out != string(data) {
+		t.Errorf("Expected the round trip to preserve the export, got %s", out)
+	}
+
+	// Test malformed files are rejected
+	if _, err := langportal(t, to, `{"wrods": []}`, "import", "-"); err == nil {
+		t.Error("Expected an unknown field to be rejected")
+	}
+}
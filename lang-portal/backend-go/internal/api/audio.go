@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateWordAudio uploads a pronunciation clip for a word
+func (s *Server) CreateWordAudio(c *gin.Context) {
+	wordID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid word ID")
+		return
+	}
+
+	// Check if word exists
+	if !s.wordExists(c, wordID) {
+		return
+	}
+
+	// Cap the request body, leaving room for the multipart envelope
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, media.MaxAudioSize+1<<20)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			problem.Abort(c, problem.FileTooLarge, "Audio file is too large")
+			return
+		}
+		problem.Abort(c, problem.InvalidBody, "Missing audio file", problem.Field("file", "is required"))
+		return
+	}
+	if header.Size > media.MaxAudioSize {
+		problem.Abort(c, problem.FileTooLarge, "Audio file is too large")
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		problem.Abort(c, problem.InvalidBody, "Failed to read audio file", problem.Field("file", err.Error()))
+		return
+	}
+	defer file.Close()
+
+	// Store the clip
+	audio, err := s.store.CreateWordAudio(c.Request.Context(), wordID, file)
+	if errors.Is(err, media.ErrUnsupportedType) {
+		problem.Abort(c, problem.UnsupportedMediaType, "Unsupported audio type")
+		return
+	} else if errors.Is(err, media.ErrTooLarge) {
+		problem.Abort(c, problem.FileTooLarge, "Audio file is too large")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to save audio")
+		return
+	}
+
+	c.JSON(http.StatusCreated, audio)
+}
+
+// GetWordAudio serves a pronunciation clip with range and caching support
+func (s *Server) GetWordAudio(c *gin.Context) {
+	wordID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid word ID")
+		return
+	}
+
+	audioID, err := strconv.Atoi(c.Param("audio_id"))
+	if err != nil {
+		invalidID(c, "audio_id", "Invalid audio ID")
+		return
+	}
+
+	audio, err := s.store.GetWordAudio(c.Request.Context(), wordID, audioID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.AudioNotFound, "Audio not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to fetch audio")
+		return
+	}
+
+	file, err := s.media.Open(audio.Filename)
+	if err != nil {
+		problem.Abort(c, problem.AudioNotFound, "Audio file not found")
+		return
+	}
+	defer file.Close()
+
+	// Clip IDs are reused after a reset or restore, so caches revalidate
+	// against an ETag naming the stored file, which is random and never reused
+	name := path.Base(audio.Filename)
+	c.Header("Content-Type", audio.ContentType)
+	c.Header("Cache-Control", "public, no-cache")
+	c.Header("ETag", `"audio-`+strings.TrimSuffix(name, path.Ext(name))+`"`)
+	http.ServeContent(c.Writer, c.Request, "", audio.CreatedAt, file)
+}
+
+// DeleteWordAudio removes a pronunciation clip from a word
+func (s *Server) DeleteWordAudio(c *gin.Context) {
+	wordID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid word ID")
+		return
+	}
+
+	audioID, err := strconv.Atoi(c.Param("audio_id"))
+	if err != nil {
+		invalidID(c, "audio_id", "Invalid audio ID")
+		return
+	}
+
+	err = s.store.DeleteWordAudio(c.Request.Context(), wordID, audioID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.AudioNotFound, "Audio not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to delete audio")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
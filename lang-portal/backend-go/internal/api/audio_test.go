@@ -0,0 +1,175 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
+)
+
+// testWAV is a minimal RIFF/WAVE header followed by silent samples
+var testWAV = append([]byte("RIFF\x24\x00\x00\x00WAVEfmt \x10\x00\x00\x00\x01\x00\x01\x00\x44\xac\x00\x00\x88\x58\x01\x00\x02\x00\x10\x00data\x00\x01\x00\x00"), make([]byte, 256)...)
+
+func TestWordAudio(t *testing.T) {
+	// Setup
+	s, conn := newTestServer(t)
+	mediaDir := s.config.MediaDir
+
+	r := testutil.SetupTestRouter()
+	r.GET("/api/words/:id", s.GetWord)
+	r.POST("/api/words/:id/audio", s.CreateWordAudio)
+	r.GET("/api/words/:id/audio/:audio_id", s.GetWordAudio)
+	r.DELETE("/api/words/:id/audio/:audio_id", s.DeleteWordAudio)
+
+	// Test upload
+	w := testutil.MakeMultipartRequest(t, r, "POST", "/api/words/1/audio", "file", "hola.wav", testWAV)
+	testutil.AssertStatus(t, w, 201)
+
+	var created map[string]interface{}
+	testutil.ParseResponse(t, w, &created)
+	if created["content_type"] != "audio/wav" {
+		t.Errorf("Expected content type 'audio/wav', got %v", created["content_type"])
+	}
+	if created["size"] != float64(len(testWAV)) {
+		t.Errorf("Expected size %d, got %v", len(testWAV), created["size"])
+	}
+	url, _ := created["url"].(string)
+	if url != "/api/words/1/audio/1" {
+		t.Errorf("Expected url '/api/words/1/audio/1', got %v", url)
+	}
+
+	// Test clip is listed on the word
+	w = testutil.MakeRequest(r, "GET", "/api/words/1", nil)
+	testutil.AssertStatus(t, w, 200)
+
+	var word struct {
+		Audio []map[string]interface{} `json:"audio"`
+	}
+	testutil.ParseResponse(t, w, &word)
+	if len(word.Audio) != 1 || word.Audio[0]["url"] != url || word.Audio[0]["created_at"] != created["created_at"] {
+		t.Errorf("Expected uploaded clip %v on word, got %v", created, word.Audio)
+	}
+
+	// Test full download with caching headers
+	w = testutil.MakeRequest(r, "GET", url, nil)
+	testutil.AssertStatus(t, w, 200)
+	if !bytes.Equal(w.Body.Bytes(), testWAV) {
+		t.Error("Expected downloaded audio to match upload")
+	}
+	if w.Header().Get("Content-Type") != "audio/wav" {
+		t.Errorf("Expected Content-Type 'audio/wav', got %q", w.Header().Get("Content-Type"))
+	}
+	if w.Header().Get("Cache-Control") != "public, no-cache" || w.Header().Get("ETag") == "" {
+		t.Errorf("Expected revalidated caching with an ETag, got %q %q", w.Header().Get("Cache-Control"), w.Header().Get("ETag"))
+	}
+	etag := w.Header().Get("ETag")
+
+	// Test range request
+	req := httptest.NewRequest("GET", url, nil)
+	req.Header.Set("Range", "bytes=0-3")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	testutil.AssertStatus(t, w, http.StatusPartialContent)
+	if w.Body.String() != "RIFF" {
+		t.Errorf("Expected partial content 'RIFF', got %q", w.Body.String())
+	}
+
+	// Test conditional request
+	req = httptest.NewRequest("GET", url, nil)
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	testutil.AssertStatus(t, w, http.StatusNotModified)
+
+	// Test unsupported type
+	w = testutil.MakeMultipartRequest(t, r, "POST", "/api/words/1/audio", "file", "notes.txt", []byte("just some text"))
+	testutil.AssertStatus(t, w, http.StatusUnsupportedMediaType)
+
+	// Test oversized upload
+	large := append(append([]byte{}, testWAV...), make([]byte, media.MaxAudioSize)...)
+	w = testutil.MakeMultipartRequest(t, r, "POST", "/api/words/1/audio", "file", "large.wav", large)
+	testutil.AssertStatus(t, w, http.StatusRequestEntityTooLarge)
+
+	// Test upload to non-existent word
+	w = testutil.MakeMultipartRequest(t, r, "POST", "/api/words/999/audio", "file", "hola.wav", testWAV)
+	testutil.AssertStatus(t, w, 404)
+
+	// Test delete
+	w = testutil.MakeRequest(r, "DELETE", url, nil)
+	testutil.AssertStatus(t, w, http.StatusNoContent)
+
+	w = testutil.MakeRequest(r, "GET", url, nil)
+	testutil.AssertStatus(t, w, 404)
+
+	files, _ := filepath.Glob(filepath.Join(mediaDir, "audio", "1", "*"))
+	if len(files) != 0 {
+		t.Errorf("Expected audio files to be removed, found %v", files)
+	}
+
+	// Test a new clip given the deleted clip's ID, as after a restore, does
+	// not match what caches hold for the old one
+	w = testutil.MakeMultipartRequest(t, r, "POST", "/api/words/1/audio", "file", "hola.wav", testWAV)
+	testutil.AssertStatus(t, w, 201)
+	if _, err := conn.Exec("UPDATE word_audio SET id = 1"); err != nil {
+		t.Fatalf("Failed to reuse the clip ID: %v", err)
+	}
+	req = httptest.NewRequest("GET", url, nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	testutil.AssertStatus(t, w, http.StatusOK)
+}
+
+func TestDeleteWord(t *testing.T) {
+	// Setup
+	s, conn := newTestServer(t)
+	mediaDir := s.config.MediaDir
+
+	r := testutil.SetupTestRouter()
+	r.GET("/api/words/:id", s.GetWord)
+	r.DELETE("/api/words/:id", s.DeleteWord)
+	r.POST("/api/words/:id/audio", s.CreateWordAudio)
+
+	w := testutil.MakeMultipartRequest(t, r, "POST", "/api/words/1/audio", "file", "hola.wav", testWAV)
+	testutil.AssertStatus(t, w, 201)
+
+	// Test delete
+	w = testutil.MakeRequest(r, "DELETE", "/api/words/1", nil)
+	testutil.AssertStatus(t, w, http.StatusNoContent)
+
+	w = testutil.MakeRequest(r, "GET", "/api/words/1", nil)
+	testutil.AssertStatus(t, w, 404)
+
+	// Verify dependent rows and files are gone
+	for _, table := range []string{"word_review_items", "word_groups", "word_audio"} {
+		var count int
+		err := conn.QueryRow("SELECT COUNT(*) FROM " + table + " WHERE word_id = 1").Scan(&count)
+		if err != nil {
+			t.Fatalf("Failed to count %s: %v", table, err)
+		}
+		if count != 0 {
+			t.Errorf("Expected 0 rows in %s for deleted word, got %d", table, count)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(mediaDir, "audio", "1"))
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("Failed to read media directory: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected audio files to be removed, found %d", len(entries))
+	}
+
+	// Test non-existent word
+	w = testutil.MakeRequest(r, "DELETE", "/api/words/999", nil)
+	testutil.AssertStatus(t, w, 404)
+
+	// Test invalid word ID format
+	w = testutil.MakeRequest(r, "DELETE", "/api/words/invalid", nil)
+	testutil.AssertStatus(t, w, 400)
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/conjugation"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// GetWordConjugations returns the conjugation table for a verb
+func (s *Server) GetWordConjugations(c *gin.Context) {
+	wordID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid word ID")
+		return
+	}
+
+	word, err := s.store.FindWord(c.Request.Context(), wordID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.WordNotFound, "Word not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to fetch word")
+		return
+	}
+
+	if !conjugation.IsVerb(word.Spanish, word.English) {
+		problem.Abort(c, problem.WordNotVerb, "Word is not a verb")
+		return
+	}
+	table, err := conjugation.Conjugate(word.Spanish)
+	if err != nil {
+		problem.Abort(c, problem.WordNotVerb, "Word is not a verb")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.WordConjugations{
+		WordID:       word.ID,
+		Infinitive:   word.Spanish,
+		English:      word.English,
+		Irregular:    conjugation.IsIrregular(word.Spanish),
+		Conjugations: table,
+	})
+}
+
+// GetConjugationDrill returns a drill question asking for one person/tense form of a verb
+func (s *Server) GetConjugationDrill(c *gin.Context) {
+	wordID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid word ID")
+		return
+	}
+
+	word, err := s.store.FindWord(c.Request.Context(), wordID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.WordNotFound, "Word not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to fetch word")
+		return
+	}
+
+	if !conjugation.IsVerb(word.Spanish, word.English) {
+		problem.Abort(c, problem.WordNotVerb, "Word is not a verb")
+		return
+	}
+
+	// Pick a random form unless the caller asked for a specific one
+	tense, person := conjugation.RandomPrompt()
+	if value := c.Query("tense"); value != "" {
+		var ok bool
+		if tense, ok = conjugation.ParseTense(value); !ok {
+			problem.Abort(c, problem.InvalidParameter, "Invalid tense", problem.Field("tense", "is not a known tense"))
+			return
+		}
+	}
+	if value := c.Query("person"); value != "" {
+		var ok bool
+		if person, ok = conjugation.ParsePerson(value); !ok {
+			problem.Abort(c, problem.InvalidParameter, "Invalid person", problem.Field("person", "is not a known person"))
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, models.ConjugationDrill{
+		WordID:     word.ID,
+		Infinitive: word.Spanish,
+		English:    word.English,
+		Tense:      tense,
+		Person:     person,
+	})
+}
+
+// CreateConjugationDrillReview checks a conjugation drill answer and records it as a word review
+func (s *Server) CreateConjugationDrillReview(c *gin.Context) {
+	// Parse parameters
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid session ID")
+		return
+	}
+
+	wordID, err := strconv.Atoi(c.Param("word_id"))
+	if err != nil {
+		invalidID(c, "word_id", "Invalid word ID")
+		return
+	}
+
+	// Get session and the word, making sure it belongs to the session's group
+	session, word, ok := s.findSessionWord(c, sessionID, wordID)
+	if !ok {
+		return
+	}
+
+	if !conjugation.IsVerb(word.Spanish, word.English) {
+		problem.Abort(c, problem.WordNotVerb, "Word is not a verb")
+		return
+	}
+	table, err := conjugation.Conjugate(word.Spanish)
+	if err != nil {
+		problem.Abort(c, problem.WordNotVerb, "Word is not a verb")
+		return
+	}
+
+	// Parse request body
+	var request struct {
+		Tense        string  `json:"tense" binding:"required,tense"`
+		Person       string  `json:"person" binding:"required,person"`
+		Answer       string  `json:"answer"`
+		ResponseTime float64 `json:"response_time" binding:"required,gt=0"`
+	}
+	if !bindJSON(c, &request) {
+		return
+	}
+	tense, _ := conjugation.ParseTense(request.Tense)
+	person, _ := conjugation.ParsePerson(request.Person)
+
+	// Grade the answer and record it
+	expected := table[tense][person]
+	correct := conjugation.Check(expected, request.Answer)
+
+	masteryLevel, err := s.store.RecordReview(c.Request.Context(), word.ID, session.StudyActivityID, correct, request.ResponseTime)
+	if err != nil {
+		internalError(c, err, "Failed to create word review")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ConjugationDrillResult{
+		WordReviewResult: models.WordReviewResult{
+			WordID:          wordID,
+			SessionID:       sessionID,
+			Correct:         correct,
+			ResponseTime:    request.ResponseTime,
+			NewMasteryLevel: masteryLevel,
+		},
+		Tense:    tense,
+		Person:   person,
+		Answer:   request.Answer,
+		Expected: expected,
+	})
+}
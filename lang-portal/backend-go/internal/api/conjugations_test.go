@@ -0,0 +1,209 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
+)
+
+// addTestVerb inserts the verb "hablar" as word 4 in Test Group 1
+func addTestVerb(t *testing.T, conn *sql.DB) {
+	t.Helper()
+	_, err := conn.Exec(`
+		INSERT INTO words (id, english, spanish, level) VALUES (4, 'to speak', 'hablar', 'beginner');
+		INSERT INTO word_groups (word_id, group_id) VALUES (4, 1);
+	`)
+	if err != nil {
+		t.Fatalf("Failed to insert test verb: %v", err)
+	}
+}
+
+func TestGetWordConjugations(t *testing.T) {
+	t.Parallel()
+	// Setup
+	s, conn := newTestServer(t)
+	addTestVerb(t, conn)
+
+	r := testutil.SetupTestRouter()
+	r.GET("/api/words/:id/conjugations", s.GetWordConjugations)
+
+	// Test verb
+	w := testutil.MakeRequest(r, "GET", "/api/words/4/conjugations", nil)
+	testutil.AssertStatus(t, w, 200)
+
+	var response struct {
+		Infinitive   string                       `json:"infinitive"`
+		Irregular    bool                         `json:"irregular"`
+		Conjugations map[string]map[string]string `json:"conjugations"`
+	}
+	testutil.ParseResponse(t, w, &response)
+
+	if response.Infinitive != "hablar" {
+		t.Errorf("Expected infinitive 'hablar', got %v", response.Infinitive)
+	}
+	if response.Irregular {
+		t.Error("Expected 'hablar' to be regular")
+	}
+	if response.Conjugations["present"]["yo"] != "hablo" {
+		t.Errorf("Expected present yo 'hablo', got %v", response.Conjugations["present"]["yo"])
+	}
+	if len(response.Conjugations) != 6 {
+		t.Errorf("Expected 6 tenses, got %d", len(response.Conjugations))
+	}
+
+	// Test word that is not a verb
+	w = testutil.MakeRequest(r, "GET", "/api/words/1/conjugations", nil)
+	testutil.AssertStatus(t, w, 400)
+
+	// Test noun ending like an infinitive
+	if _, err := conn.Exec(`INSERT INTO words (id, english, spanish, level) VALUES (5, 'woman', 'mujer', 'beginner')`); err != nil {
+		t.Fatalf("Failed to insert test noun: %v", err)
+	}
+	w = testutil.MakeRequest(r, "GET", "/api/words/5/conjugations", nil)
+	testutil.AssertProblem(t, w, problem.WordNotVerb)
+
+	// Test non-existent word
+	w = testutil.MakeRequest(r, "GET", "/api/words/999/conjugations", nil)
+	testutil.AssertStatus(t, w, 404)
+
+	// Test invalid word ID format
+	w = testutil.MakeRequest(r, "GET", "/api/words/invalid/conjugations", nil)
+	testutil.AssertStatus(t, w, 400)
+}
+
+func TestGetConjugationDrill(t *testing.T) {
+	t.Parallel()
+	// Setup
+	s, conn := newTestServer(t)
+	addTestVerb(t, conn)
+
+	r := testutil.SetupTestRouter()
+	r.GET("/api/words/:id/conjugations/drill", s.GetConjugationDrill)
+
+	// Test random prompt
+	w := testutil.MakeRequest(r, "GET", "/api/words/4/conjugations/drill", nil)
+	testutil.AssertStatus(t, w, 200)
+
+	var response map[string]interface{}
+	testutil.ParseResponse(t, w, &response)
+
+	if response["tense"] == nil || response["person"] == nil {
+		t.Errorf("Expected tense and person in response, got %v", response)
+	}
+
+	// Test requested prompt
+	w = testutil.MakeRequest(r, "GET", "/api/words/4/conjugations/drill?tense=future&person=tu", nil)
+	testutil.AssertStatus(t, w, 200)
+
+	testutil.ParseResponse(t, w, &response)
+	if response["tense"] != "future" || response["person"] != "tu" {
+		t.Errorf("Expected future/tu prompt, got %v/%v", response["tense"], response["person"])
+	}
+
+	// Test invalid tense
+	w = testutil.MakeRequest(r, "GET", "/api/words/4/conjugations/drill?tense=pluperfect", nil)
+	testutil.AssertStatus(t, w, 400)
+
+	// Test word that is not a verb
+	w = testutil.MakeRequest(r, "GET", "/api/words/1/conjugations/drill", nil)
+	testutil.AssertStatus(t, w, 400)
+}
+
+func TestCreateConjugationDrillReview(t *testing.T) {
+	t.Parallel()
+	// Setup
+	s, conn := newTestServer(t)
+	addTestVerb(t, conn)
+
+	r := testutil.SetupTestRouter()
+	r.POST("/api/study_sessions/:id/words/:word_id/conjugation_drill", s.CreateConjugationDrillReview)
+
+	// Test correct answer
+	body, _ := json.Marshal(map[string]interface{}{
+		"tense":         "preterite",
+		"person":        "el",
+		"answer":        "habló",
+		"response_time": 2.5,
+	})
+	w := testutil.MakeRequest(r, "POST", "/api/study_sessions/1/words/4/conjugation_drill", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 200)
+
+	var response map[string]interface{}
+	testutil.ParseResponse(t, w, &response)
+
+	if response["correct"] != true {
+		t.Errorf("Expected answer to be correct, got %v", response)
+	}
+	if response["expected"] != "habló" {
+		t.Errorf("Expected form 'habló', got %v", response["expected"])
+	}
+	if response["new_mastery_level"] != float64(1) {
+		t.Errorf("Expected mastery level 1, got %v", response["new_mastery_level"])
+	}
+
+	// Test incorrect answer is recorded
+	body, _ = json.Marshal(map[string]interface{}{
+		"tense":         "preterite",
+		"person":        "el",
+		"answer":        "hablo",
+		"response_time": 2.5,
+	})
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/words/4/conjugation_drill", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 200)
+
+	testutil.ParseResponse(t, w, &response)
+	if response["correct"] != false {
+		t.Error("Expected answer to be incorrect")
+	}
+	if response["new_mastery_level"] != float64(0.5) {
+		t.Errorf("Expected mastery level 0.5, got %v", response["new_mastery_level"])
+	}
+
+	var reviewCount int
+	err := conn.QueryRow("SELECT COUNT(*) FROM word_review_items WHERE word_id = 4").Scan(&reviewCount)
+	if err != nil {
+		t.Fatalf("Failed to count reviews: %v", err)
+	}
+	if reviewCount != 2 {
+		t.Errorf("Expected 2 reviews recorded, got %d", reviewCount)
+	}
+
+	// Test invalid person
+	body, _ = json.Marshal(map[string]interface{}{
+		"tense":         "present",
+		"person":        "usted",
+		"answer":        "habla",
+		"response_time": 1.0,
+	})
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/words/4/conjugation_drill", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 422)
+	p := testutil.AssertProblem(t, w, problem.ValidationFailed)
+	assertFields(t, p, "person")
+
+	// Test response time is required and must be positive
+	for _, invalid := range []string{
+		`{"tense": "present", "person": "yo", "answer": "hablo"}`,
+		`{"tense": "present", "person": "yo", "answer": "hablo", "response_time": 0}`,
+	} {
+		w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/words/4/conjugation_drill", bytes.NewBufferString(invalid))
+		testutil.AssertStatus(t, w, 422)
+		p = testutil.AssertProblem(t, w, problem.ValidationFailed)
+		assertFields(t, p, "response_time")
+	}
+
+	// Test word that is not a verb
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/words/1/conjugation_drill", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 400)
+
+	// Test word outside the session's group
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/2/words/4/conjugation_drill", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 404)
+
+	// Test non-existent session
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/999/words/4/conjugation_drill", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 404)
+}
@@ -3,17 +3,16 @@ package api
 import (
 	"testing"
 
-	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
 )
 
 func TestGetLastStudySession(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, conn := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/dashboard/last_study_session", GetLastStudySession)
+	r.GET("/api/dashboard/last_study_session", s.GetLastStudySession)
 
 	// Test successful case
 	w := testutil.MakeRequest(r, "GET", "/api/dashboard/last_study_session", nil)
@@ -55,18 +54,18 @@ func TestGetLastStudySession(t *testing.T) {
 	}
 
 	// Test database error
-	db.GetDB().Close()
+	conn.Close()
 	w = testutil.MakeRequest(r, "GET", "/api/dashboard/last_study_session", nil)
 	testutil.AssertStatus(t, w, 500)
 }
 
 func TestGetStudyProgress(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, conn := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/dashboard/study_progress", GetStudyProgress)
+	r.GET("/api/dashboard/study_progress", s.GetStudyProgress)
 
 	// Test successful case
 	w := testutil.MakeRequest(r, "GET", "/api/dashboard/study_progress", nil)
@@ -102,18 +101,18 @@ func TestGetStudyProgress(t *testing.T) {
 	}
 
 	// Test database error
-	db.GetDB().Close()
+	conn.Close()
 	w = testutil.MakeRequest(r, "GET", "/api/dashboard/study_progress", nil)
 	testutil.AssertStatus(t, w, 500)
 }
 
 func TestGetQuickStats(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, conn := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/dashboard/quick_stats", GetQuickStats)
+	r.GET("/api/dashboard/quick_stats", s.GetQuickStats)
 
 	// Test successful case
 	w := testutil.MakeRequest(r, "GET", "/api/dashboard/quick_stats", nil)
@@ -159,7 +158,7 @@ func TestGetQuickStats(t *testing.T) {
 	}
 
 	// Test database error
-	db.GetDB().Close()
+	conn.Close()
 	w = testutil.MakeRequest(r, "GET", "/api/dashboard/quick_stats", nil)
 	testutil.AssertStatus(t, w, 500)
 }
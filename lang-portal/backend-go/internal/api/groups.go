@@ -1,272 +1,123 @@
 package api
 
 import (
-	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 
-	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
 	"github.com/gin-gonic/gin"
 )
 
-// GetGroups returns a list of all word groups
-func GetGroups(c *gin.Context) {
-	db := db.GetDB()
-
-	rows, err := db.Query(`
-		SELECT 
-			g.id,
-			g.name,
-			g.created_at,
-			g.updated_at,
-			COUNT(DISTINCT wg.word_id) as total_word_count,
-			COUNT(DISTINCT CASE WHEN wri.correct = 1 THEN w.id END) as mastered_words,
-			COUNT(DISTINCT CASE WHEN wri.correct = 0 THEN w.id END) as in_progress_words,
-			COALESCE(AVG(CASE WHEN wri.correct THEN 1.0 ELSE 0.0 END), 0) as average_mastery
-		FROM groups g
-		LEFT JOIN word_groups wg ON g.id = wg.group_id
-		LEFT JOIN words w ON wg.word_id = w.id
-		LEFT JOIN word_review_items wri ON w.id = wri.word_id
-		GROUP BY g.id
-		ORDER BY g.name
-	`)
+// GetGroups returns a paginated list of word groups
+func (s *Server) GetGroups(c *gin.Context) {
+	// Parse pagination parameters
+	page, err := pagination.Parse(c, "name:asc", 2)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch groups"})
+		invalidPagination(c, err)
 		return
 	}
-	defer rows.Close()
 
-	var groups []models.GroupWithStats
-	for rows.Next() {
-		var group models.GroupWithStats
-		err := rows.Scan(
-			&group.ID,
-			&group.Name,
-			&group.CreatedAt,
-			&group.UpdatedAt,
-			&group.Statistics.TotalWordCount,
-			&group.Statistics.MasteredWords,
-			&group.Statistics.InProgressWords,
-			&group.Statistics.AverageMastery,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan group"})
-			return
-		}
-		groups = append(groups, group)
+	groups, err := s.store.ListGroups(c.Request.Context(), page)
+	if err != nil {
+		internalError(c, err, "Failed to fetch groups")
+		return
 	}
 
-	c.JSON(http.StatusOK, groups)
+	pagination.Respond(c, page, groups, func(group models.GroupWithStats) []interface{} {
+		return []interface{}{group.Name, group.ID}
+	})
 }
 
 // GetGroup returns details for a specific group
-func GetGroup(c *gin.Context) {
-	db := db.GetDB()
-
+func (s *Server) GetGroup(c *gin.Context) {
 	groupID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID"})
+		invalidID(c, "id", "Invalid group ID")
 		return
 	}
 
-	var group models.GroupWithStats
-	err = db.QueryRow(`
-		SELECT 
-			g.id,
-			g.name,
-			g.created_at,
-			g.updated_at,
-			COUNT(DISTINCT wg.word_id) as total_word_count,
-			COUNT(DISTINCT CASE WHEN wri.correct = 1 THEN w.id END) as mastered_words,
-			COUNT(DISTINCT CASE WHEN wri.correct = 0 THEN w.id END) as in_progress_words,
-			COALESCE(AVG(CASE WHEN wri.correct THEN 1.0 ELSE 0.0 END), 0) as average_mastery
-		FROM groups g
-		LEFT JOIN word_groups wg ON g.id = wg.group_id
-		LEFT JOIN words w ON wg.word_id = w.id
-		LEFT JOIN word_review_items wri ON w.id = wri.word_id
-		WHERE g.id = ?
-		GROUP BY g.id
-	`, groupID).Scan(
-		&group.ID,
-		&group.Name,
-		&group.CreatedAt,
-		&group.UpdatedAt,
-		&group.Statistics.TotalWordCount,
-		&group.Statistics.MasteredWords,
-		&group.Statistics.InProgressWords,
-		&group.Statistics.AverageMastery,
-	)
-
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Group not found"})
+	group, err := s.store.GetGroup(c.Request.Context(), groupID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.GroupNotFound, "Group not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch group"})
+		internalError(c, err, "Failed to fetch group")
 		return
 	}
 
 	c.JSON(http.StatusOK, group)
 }
 
-// GetGroupWords returns all words in a specific group
-func GetGroupWords(c *gin.Context) {
-	db := db.GetDB()
-
+// GetGroupWords returns a paginated list of the words in a specific group
+func (s *Server) GetGroupWords(c *gin.Context) {
 	groupID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID"})
+		invalidID(c, "id", "Invalid group ID")
 		return
 	}
 
-	// Check if group exists
-	var exists bool
-	err = db.QueryRow("SELECT EXISTS(SELECT 1 FROM groups WHERE id = ?)", groupID).Scan(&exists)
+	// Parse pagination parameters
+	page, err := pagination.Parse(c, "english:asc", 2)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check group existence"})
-		return
-	}
-	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Group not found"})
+		invalidPagination(c, err)
 		return
 	}
 
-	rows, err := db.Query(`
-		SELECT 
-			w.id,
-			w.english,
-			w.spanish,
-			w.level,
-			w.created_at,
-			w.updated_at,
-			COUNT(CASE WHEN wri.correct = 1 THEN 1 END) as correct_count,
-			COUNT(CASE WHEN wri.correct = 0 THEN 1 END) as incorrect_count,
-			COALESCE(AVG(CASE WHEN wri.correct THEN 1.0 ELSE 0.0 END), 0) as mastery_level
-		FROM words w
-		JOIN word_groups wg ON w.id = wg.word_id
-		LEFT JOIN word_review_items wri ON w.id = wri.word_id
-		WHERE wg.group_id = ?
-		GROUP BY w.id
-		ORDER BY w.english
-	`, groupID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch group words"})
+	words, err := s.store.ListGroupWords(c.Request.Context(), groupID, page)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.GroupNotFound, "Group not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to fetch group words")
 		return
-	}
-	defer rows.Close()
-
-	var words []models.WordWithStats
-	for rows.Next() {
-		var word models.WordWithStats
-		err := rows.Scan(
-			&word.ID,
-			&word.English,
-			&word.Spanish,
-			&word.Level,
-			&word.CreatedAt,
-			&word.UpdatedAt,
-			&word.CorrectCount,
-			&word.IncorrectCount,
-			&word.MasteryLevel,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan word"})
-			return
-		}
-		words = append(words, word)
 	}
 
-	c.JSON(http.StatusOK, words)
+	pagination.Respond(c, page, words, wordEnglishKey)
 }
 
-// GetGroupStudySessions returns all study sessions for a specific group
-func GetGroupStudySessions(c *gin.Context) {
-	db := db.GetDB()
-
+// GetGroupStudySessions returns a paginated list of the study sessions for a specific group
+func (s *Server) GetGroupStudySessions(c *gin.Context) {
 	groupID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID"})
+		invalidID(c, "id", "Invalid group ID")
 		return
 	}
 
-	rows, err := db.Query(`
-		SELECT 
-			ss.id,
-			sa.name as activity_name,
-			g.name as group_name,
-			ss.created_at as start_time,
-			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_activity_id = ss.study_activity_id) as review_items_count,
-			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_activity_id = ss.study_activity_id AND wri.correct = 1) as correct_count,
-			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_activity_id = ss.study_activity_id AND wri.correct = 0) as incorrect_count
-		FROM study_sessions ss
-		JOIN study_activities sa ON ss.study_activity_id = sa.id
-		JOIN groups g ON ss.group_id = g.id
-		WHERE ss.group_id = ?
-		ORDER BY ss.created_at DESC
-	`, groupID)
+	// Parse pagination parameters
+	page, err := pagination.Parse(c, sessionOrder, 2)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch group study sessions"})
+		invalidPagination(c, err)
 		return
 	}
-	defer rows.Close()
 
-	var sessions []models.StudySessionWithStats
-	for rows.Next() {
-		var session models.StudySessionWithStats
-		err := rows.Scan(
-			&session.ID,
-			&session.ActivityName,
-			&session.GroupName,
-			&session.StartTime,
-			&session.ReviewItemsCount,
-			&session.CorrectCount,
-			&session.IncorrectCount,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan study session"})
-			return
-		}
-
-		// Calculate score as percentage
-		if session.ReviewItemsCount > 0 {
-			session.Score = (session.CorrectCount * 100) / session.ReviewItemsCount
-		}
-
-		sessions = append(sessions, session)
+	sessions, err := s.store.ListStudySessions(c.Request.Context(), store.SessionFilter{GroupID: groupID}, page)
+	if err != nil {
+		internalError(c, err, "Failed to fetch group study sessions")
+		return
 	}
 
-	c.JSON(http.StatusOK, sessions)
+	pagination.Respond(c, page, sessions, sessionKey)
 }
 
 // CreateGroup creates a new word group
-func CreateGroup(c *gin.Context) {
-	db := db.GetDB()
-
+func (s *Server) CreateGroup(c *gin.Context) {
 	// Parse request body
-	var group models.Group
-	if err := c.ShouldBindJSON(&group); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
-		return
+	var request struct {
+		Name string `json:"name" binding:"required,notblank"`
 	}
-
-	// Insert the group
-	result, err := db.Exec(`
-		INSERT INTO groups (name, created_at, updated_at)
-		VALUES (?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-	`, group.Name)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create group"})
+	if !bindJSON(c, &request) {
 		return
 	}
 
-	// Get the inserted ID
-	groupID, err := result.LastInsertId()
+	group, err := s.store.CreateGroup(c.Request.Context(), request.Name)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get group ID"})
+		internalError(c, err, "Failed to create group")
 		return
 	}
 
-	// Return the created group
-	group.ID = int(groupID)
 	c.JSON(http.StatusCreated, group)
 }
@@ -3,17 +3,16 @@ package api
 import (
 	"testing"
 
-	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
 )
 
 func TestGetGroups(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, _ := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/groups", GetGroups)
+	r.GET("/api/groups", s.GetGroups)
 
 	// Test
 	w := testutil.MakeRequest(r, "GET", "/api/groups", nil)
@@ -22,7 +21,7 @@ func TestGetGroups(t *testing.T) {
 	testutil.AssertStatus(t, w, 200)
 
 	var response []map[string]interface{}
-	testutil.ParseResponse(t, w, &response)
+	testutil.ParseItems(t, w, &response)
 
 	if len(response) != 2 {
 		t.Errorf("Expected 2 groups, got %d", len(response))
@@ -36,12 +35,12 @@ func TestGetGroups(t *testing.T) {
 }
 
 func TestGetGroup(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, _ := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/groups/:id", GetGroup)
+	r.GET("/api/groups/:id", s.GetGroup)
 
 	// Test existing group
 	w := testutil.MakeRequest(r, "GET", "/api/groups/1", nil)
@@ -66,19 +65,19 @@ func TestGetGroup(t *testing.T) {
 }
 
 func TestGetGroupWords(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, conn := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/groups/:id/words", GetGroupWords)
+	r.GET("/api/groups/:id/words", s.GetGroupWords)
 
 	// Test successful case
 	w := testutil.MakeRequest(r, "GET", "/api/groups/1/words", nil)
 	testutil.AssertStatus(t, w, 200)
 
 	var response []map[string]interface{}
-	testutil.ParseResponse(t, w, &response)
+	testutil.ParseItems(t, w, &response)
 
 	if len(response) != 2 {
 		t.Errorf("Expected 2 words in group, got %d", len(response))
@@ -145,25 +144,25 @@ func TestGetGroupWords(t *testing.T) {
 	testutil.AssertStatus(t, w, 400)
 
 	// Test database error
-	db.GetDB().Close()
+	conn.Close()
 	w = testutil.MakeRequest(r, "GET", "/api/groups/1/words", nil)
 	testutil.AssertStatus(t, w, 500)
 }
 
 func TestGetGroupStudySessions(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, _ := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/groups/:id/study_sessions", GetGroupStudySessions)
+	r.GET("/api/groups/:id/study_sessions", s.GetGroupStudySessions)
 
 	// Test
 	w := testutil.MakeRequest(r, "GET", "/api/groups/1/study_sessions", nil)
 	testutil.AssertStatus(t, w, 200)
 
 	var response []map[string]interface{}
-	testutil.ParseResponse(t, w, &response)
+	testutil.ParseItems(t, w, &response)
 
 	if len(response) != 1 {
 		t.Errorf("Expected 1 study session for group, got %d", len(response))
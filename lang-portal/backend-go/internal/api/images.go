@@ -0,0 +1,152 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateWordImage uploads a picture for a word, reusing any identical image already stored
+func (s *Server) CreateWordImage(c *gin.Context) {
+	wordID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid word ID")
+		return
+	}
+
+	// Check if word exists
+	if !s.wordExists(c, wordID) {
+		return
+	}
+
+	// Cap the request body, leaving room for the multipart envelope
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, media.MaxImageSize+1<<20)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			problem.Abort(c, problem.FileTooLarge, "Image file is too large")
+			return
+		}
+		problem.Abort(c, problem.InvalidBody, "Missing image file", problem.Field("file", "is required"))
+		return
+	}
+	if header.Size > media.MaxImageSize {
+		problem.Abort(c, problem.FileTooLarge, "Image file is too large")
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		problem.Abort(c, problem.InvalidBody, "Failed to read image file", problem.Field("file", err.Error()))
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, media.MaxImageSize+1))
+	if err != nil {
+		problem.Abort(c, problem.InvalidBody, "Failed to read image file", problem.Field("file", err.Error()))
+		return
+	}
+
+	// Store the image, reusing an existing image with the same content, and link it to the word
+	image, created, err := s.store.CreateWordImage(c.Request.Context(), wordID, data)
+	if errors.Is(err, media.ErrUnsupportedType) {
+		problem.Abort(c, problem.UnsupportedMediaType, "Unsupported image type")
+		return
+	} else if errors.Is(err, media.ErrTooLarge) {
+		problem.Abort(c, problem.FileTooLarge, "Image is too large")
+		return
+	} else if errors.Is(err, media.ErrInvalidImage) {
+		problem.Abort(c, problem.InvalidBody, "Invalid image file", problem.Field("file", err.Error()))
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to store image")
+		return
+	}
+
+	status := http.StatusCreated
+	if !created {
+		status = http.StatusOK
+	}
+
+	c.JSON(status, image)
+}
+
+// GetImage serves an uploaded image
+func (s *Server) GetImage(c *gin.Context) {
+	s.serveImage(c, false)
+}
+
+// GetImageThumbnail serves the thumbnail generated for an uploaded image
+func (s *Server) GetImageThumbnail(c *gin.Context) {
+	s.serveImage(c, true)
+}
+
+// DeleteWordImage unlinks an image from a word, removing the image once no word uses it
+func (s *Server) DeleteWordImage(c *gin.Context) {
+	wordID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid word ID")
+		return
+	}
+
+	imageID, err := strconv.Atoi(c.Param("image_id"))
+	if err != nil {
+		invalidID(c, "image_id", "Invalid image ID")
+		return
+	}
+
+	err = s.store.DeleteWordImage(c.Request.Context(), wordID, imageID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.ImageNotFound, "Image not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to unlink image")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// serveImage serves an image or its thumbnail with range and caching support
+func (s *Server) serveImage(c *gin.Context, thumbnail bool) {
+	imageID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid image ID")
+		return
+	}
+
+	image, err := s.store.GetImage(c.Request.Context(), imageID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.ImageNotFound, "Image not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to fetch image")
+		return
+	}
+
+	filename, contentType, etag := image.Filename, image.ContentType, image.SHA256
+	if thumbnail {
+		filename, contentType, etag = image.ThumbnailFilename, media.ThumbnailContentType(image.ContentType), image.SHA256+"-thumb"
+	}
+
+	file, err := s.media.Open(filename)
+	if err != nil {
+		problem.Abort(c, problem.ImageNotFound, "Image file not found")
+		return
+	}
+	defer file.Close()
+
+	// Images are content-addressed, so they can be cached indefinitely
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("ETag", `"`+etag+`"`)
+	http.ServeContent(c.Writer, c.Request, "", image.CreatedAt, file)
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
+)
+
+// testPNG returns an encoded single-color PNG of the given size
+func testPNG(t *testing.T, width, height int, c color.Color) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("Failed to encode test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestWordImages(t *testing.T) {
+	// Setup
+	s, _ := newTestServer(t)
+	mediaDir := s.config.MediaDir
+
+	r := testutil.SetupTestRouter()
+	r.GET("/api/words", s.GetWords)
+	r.GET("/api/words/:id", s.GetWord)
+	r.POST("/api/words/:id/images", s.CreateWordImage)
+	r.DELETE("/api/words/:id/images/:image_id", s.DeleteWordImage)
+	r.GET("/api/images/:id", s.GetImage)
+	r.GET("/api/images/:id/thumbnail", s.GetImageThumbnail)
+
+	picture := testPNG(t, 400, 300, color.RGBA{G: 255, A: 255})
+
+	// Test upload
+	w := testutil.MakeMultipartRequest(t, r, "POST", "/api/words/1/images", "file", "hello.png", picture)
+	testutil.AssertStatus(t, w, 201)
+
+	var created map[string]interface{}
+	testutil.ParseResponse(t, w, &created)
+	if created["width"] != float64(400) || created["height"] != float64(300) {
+		t.Errorf("Unexpected image dimensions: %v", created)
+	}
+	if created["url"] != "/api/images/1" || created["thumbnail_url"] != "/api/images/1/thumbnail" {
+		t.Errorf("Unexpected image URLs: %v", created)
+	}
+
+	// Test identical content is de-duplicated across words
+	w = testutil.MakeMultipartRequest(t, r, "POST", "/api/words/2/images", "file", "copy.png", picture)
+	testutil.AssertStatus(t, w, 201)
+	var duplicate map[string]interface{}
+	testutil.ParseResponse(t, w, &duplicate)
+	if duplicate["id"] != created["id"] {
+		t.Errorf("Expected duplicate upload to reuse image %v, got %v", created["id"], duplicate["id"])
+	}
+
+	// Test re-uploading to the same word is a no-op
+	w = testutil.MakeMultipartRequest(t, r, "POST", "/api/words/1/images", "file", "hello.png", picture)
+	testutil.AssertStatus(t, w, 200)
+
+	files, _ := filepath.Glob(filepath.Join(mediaDir, "images", "*", "*"))
+	if len(files) != 2 {
+		t.Errorf("Expected one image and one thumbnail on disk, got %v", files)
+	}
+
+	// Test image URLs are exposed on word lists and details
+	w = testutil.MakeRequest(r, "GET", "/api/words", nil)
+	testutil.AssertStatus(t, w, 200)
+	var list struct {
+		Words []struct {
+			ID     int                      `json:"id"`
+			Images []map[string]interface{} `json:"images"`
+		} `json:"items"`
+	}
+	testutil.ParseResponse(t, w, &list)
+	for _, word := range list.Words {
+		expected := 0
+		if word.ID == 1 || word.ID == 2 {
+			expected = 1
+		}
+		if len(word.Images) != expected {
+			t.Errorf("Expected %d images for word %d, got %v", expected, word.ID, word.Images)
+		}
+	}
+
+	w = testutil.MakeRequest(r, "GET", "/api/words/1", nil)
+	testutil.AssertStatus(t, w, 200)
+	var detail struct {
+		Images []map[string]interface{} `json:"images"`
+	}
+	testutil.ParseResponse(t, w, &detail)
+	if len(detail.Images) != 1 || detail.Images[0]["thumbnail_url"] != "/api/images/1/thumbnail" {
+		t.Errorf("Expected image on word detail, got %v", detail.Images)
+	}
+
+	// Test serving the image and thumbnail
+	w = testutil.MakeRequest(r, "GET", "/api/images/1", nil)
+	testutil.AssertStatus(t, w, 200)
+	if !bytes.Equal(w.Body.Bytes(), picture) {
+		t.Error("Expected served image to match upload")
+	}
+	if w.Header().Get("Cache-Control") == "" || w.Header().Get("ETag") == "" {
+		t.Error("Expected Cache-Control and ETag headers")
+	}
+
+	w = testutil.MakeRequest(r, "GET", "/api/images/1/thumbnail", nil)
+	testutil.AssertStatus(t, w, 200)
+	config, err := png.DecodeConfig(w.Body)
+	if err != nil {
+		t.Fatalf("Failed to decode thumbnail: %v", err)
+	}
+	if config.Width != 200 || config.Height != 150 {
+		t.Errorf("Expected 200x150 thumbnail, got %dx%d", config.Width, config.Height)
+	}
+
+	// Test unsupported type
+	w = testutil.MakeMultipartRequest(t, r, "POST", "/api/words/1/images", "file", "notes.txt", []byte("just some text"))
+	testutil.AssertStatus(t, w, http.StatusUnsupportedMediaType)
+
+	// Test unlinking keeps the image while another word uses it
+	w = testutil.MakeRequest(r, "DELETE", "/api/words/1/images/1", nil)
+	testutil.AssertStatus(t, w, http.StatusNoContent)
+	w = testutil.MakeRequest(r, "GET", "/api/images/1", nil)
+	testutil.AssertStatus(t, w, 200)
+
+	// Test unlinking the last word removes the image
+	w = testutil.MakeRequest(r, "DELETE", "/api/words/2/images/1", nil)
+	testutil.AssertStatus(t, w, http.StatusNoContent)
+	w = testutil.MakeRequest(r, "GET", "/api/images/1", nil)
+	testutil.AssertStatus(t, w, 404)
+
+	files, _ = filepath.Glob(filepath.Join(mediaDir, "images", "*", "*"))
+	if len(files) != 0 {
+		t.Errorf("Expected image files to be removed, got %v", files)
+	}
+
+	// Test unlinking an image that is not linked
+	w = testutil.MakeRequest(r, "DELETE", "/api/words/2/images/1", nil)
+	testutil.AssertStatus(t, w, 404)
+}
+
+func TestWordImagesConcurrentUpload(t *testing.T) {
+	// Setup
+	s, conn := newTestServer(t)
+	r := testutil.SetupTestRouter()
+	r.POST("/api/words/:id/images", s.CreateWordImage)
+	data := testPNG(t, 8, 8, color.RGBA{0, 0, 255, 255})
+
+	// Test the same image uploaded for several words at once is stored once
+	var wg sync.WaitGroup
+	codes := make([]int, 3)
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := testutil.MakeMultipartRequest(t, r, "POST", "/api/words/"+strconv.Itoa(i+1)+"/images", "file", "blue.png", data)
+			codes[i] = w.Code
+		}(i)
+	}
+	wg.Wait()
+	for i, code := range codes {
+		if code != http.StatusCreated {
+			t.Errorf("Expected upload %d to be created, got %d", i+1, code)
+		}
+	}
+
+	var images, links int
+	conn.QueryRow("SELECT COUNT(*) FROM images").Scan(&images)
+	conn.QueryRow("SELECT COUNT(*) FROM word_images").Scan(&links)
+	if images != 1 || links != 3 {
+		t.Errorf("Expected 1 image linked to 3 words, got %d images and %d links", images, links)
+	}
+}
+
+func TestWordImagesConcurrentUnlink(t *testing.T) {
+	// Setup
+	s, _ := newTestServer(t)
+	r := testutil.SetupTestRouter()
+	r.POST("/api/words/:id/images", s.CreateWordImage)
+	r.DELETE("/api/words/:id/images/:image_id", s.DeleteWordImage)
+	r.GET("/api/images/:id", s.GetImage)
+	r.GET("/api/images/:id/thumbnail", s.GetImageThumbnail)
+	data := testPNG(t, 8, 8, color.RGBA{0, 255, 0, 255})
+
+	// Test an image unlinked from its last word while another word uploads
+	// it keeps the row and files it is served from
+	for round := 0; round < 20; round++ {
+		w := testutil.MakeMultipartRequest(t, r, "POST", "/api/words/1/images", "file", "green.png", data)
+		testutil.AssertStatus(t, w, http.StatusCreated)
+		var first struct {
+			ID int `json:"id"`
+		}
+		testutil.ParseResponse(t, w, &first)
+
+		var wg sync.WaitGroup
+		var upload *httptest.ResponseRecorder
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			testutil.MakeRequest(r, "DELETE", "/api/words/1/images/"+strconv.Itoa(first.ID), nil)
+		}()
+		go func() {
+			defer wg.Done()
+			upload = testutil.MakeMultipartRequest(t, r, "POST", "/api/words/2/images", "file", "green.png", data)
+		}()
+		wg.Wait()
+		testutil.AssertStatus(t, upload, http.StatusCreated)
+		var second struct {
+			ID  int    `json:"id"`
+			URL string `json:"url"`
+		}
+		testutil.ParseResponse(t, upload, &second)
+
+		for _, url := range []string{second.URL, second.URL + "/thumbnail"} {
+			if w := testutil.MakeRequest(r, "GET", url, nil); w.Code != http.StatusOK {
+				t.Fatalf("Expected %s to be served in round %d, got %d", url, round+1, w.Code)
+			}
+		}
+		w = testutil.MakeRequest(r, "DELETE", "/api/words/2/images/"+strconv.Itoa(second.ID), nil)
+		testutil.AssertStatus(t, w, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed openapi.yaml
+var openAPIYAML []byte
+
+// openAPIDocument parses openapi.yaml and renders it as JSON, once
+var openAPIDocument = sync.OnceValues(func() ([]byte, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(openAPIYAML, &doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+})
+
+// GetOpenAPI serves the OpenAPI description of the API
+func (s *Server) GetOpenAPI(c *gin.Context) {
+	doc, err := openAPIDocument()
+	if err != nil {
+		internalError(c, err, "Failed to load API description")
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", doc)
+}
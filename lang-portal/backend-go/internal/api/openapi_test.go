@@ -0,0 +1,250 @@
+package api
+
+import (
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/config"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/conjugation"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/quiz"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
+	"github.com/gin-gonic/gin"
+)
+
+// openAPISpec is the part of the OpenAPI document the drift tests inspect
+type openAPISpec struct {
+	OpenAPI    string                            `json:"openapi"`
+	Paths      map[string]map[string]interface{} `json:"paths"`
+	Components struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPISchema struct {
+	Ref        string                    `json:"$ref"`
+	Enum       []string                  `json:"enum"`
+	Properties map[string]*openAPISchema `json:"properties"`
+	AllOf      []*openAPISchema          `json:"allOf"`
+}
+
+// schemaModels maps each component schema to the Go type it describes.
+// Message describes gin.H bodies and has no type of its own.
+var schemaModels = map[string]interface{}{
+	"Problem":                problem.Problem{},
+	"FieldError":             problem.FieldError{},
+	"PaginationInfo":         pagination.Info{},
+	"Word":                   models.Word{},
+	"WordWithStats":          models.WordWithStats{},
+	"WordImage":              models.WordImage{},
+	"WordAudio":              models.WordAudio{},
+	"WordDetail":             models.WordDetail{},
+	"WordPage":               pagination.Page[models.WordWithStats]{},
+	"Image":                  models.Image{},
+	"Group":                  models.Group{},
+	"GroupWithStats":         models.GroupWithStats{},
+	"GroupPage":              pagination.Page[models.GroupWithStats]{},
+	"Tag":                    models.Tag{},
+	"TagWithStats":           models.TagWithStats{},
+	"TagPage":                pagination.Page[models.TagWithStats]{},
+	"StudyActivity":          models.StudyActivity{},
+	"StudyActivityWithStats": models.StudyActivityWithStats{},
+	"StudySession":           models.StudySession{},
+	"StudySessionWithStats":  models.StudySessionWithStats{},
+	"StudySessionPage":       pagination.Page[models.StudySessionWithStats]{},
+	"WordReviewResult":       models.WordReviewResult{},
+	"WordConjugations":       models.WordConjugations{},
+	"ConjugationDrill":       models.ConjugationDrill{},
+	"ConjugationDrillResult": models.ConjugationDrillResult{},
+	"QuizQuestion":           quiz.Question{},
+	"Quiz":                   models.Quiz{},
+	"QuizAnswerResult":       models.QuizAnswerResult{},
+	"StudyProgress":          models.StudyProgress{},
+	"QuickStats":             models.QuickStats{},
+	"Backup":                 models.Backup{},
+	"Config":                 config.Config{},
+}
+
+// schemaEnums maps each enum schema to the values the Go code accepts
+var schemaEnums = map[string][]string{
+	"Tense":        toStrings(conjugation.Tenses),
+	"Person":       toStrings(conjugation.Persons),
+	"QuestionType": toStrings(quiz.Types),
+	"ProblemCode":  toStrings(problem.Codes()),
+}
+
+var untypedSchemas = []string{"Message"}
+
+func toStrings[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// loadOpenAPI fetches the document from the API, so the handler is exercised
+// along with the spec
+func loadOpenAPI(t *testing.T) *openAPISpec {
+	t.Helper()
+	s := NewServer(failingStore{}, config.Default())
+	r := testutil.SetupTestRouter()
+	r.GET("/api/openapi.json", s.GetOpenAPI)
+
+	w := testutil.MakeRequest(r, "GET", "/api/openapi.json", nil)
+	testutil.AssertStatus(t, w, 200)
+	var spec openAPISpec
+	testutil.ParseResponse(t, w, &spec)
+	return &spec
+}
+
+// resolve follows a local schema reference
+func (spec *openAPISpec) resolve(t *testing.T, s *openAPISchema) *openAPISchema {
+	t.Helper()
+	if s.Ref == "" {
+		return s
+	}
+	name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+	target, ok := spec.Components.Schemas[name]
+	if !ok {
+		t.Fatalf("Unresolved schema reference %s", s.Ref)
+	}
+	return spec.resolve(t, target)
+}
+
+// properties lists the property names of a schema, including those it
+// inherits through allOf
+func (spec *openAPISpec) properties(t *testing.T, s *openAPISchema) []string {
+	t.Helper()
+	s = spec.resolve(t, s)
+	var names []string
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	for _, part := range s.AllOf {
+		names = append(names, spec.properties(t, part)...)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jsonFields lists the JSON field names of a struct type, flattening embedded
+// structs the way encoding/json does
+func jsonFields(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+			continue
+		case f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct:
+			names = append(names, jsonFields(f.Type)...)
+			continue
+		case !f.IsExported():
+			continue
+		case name == "":
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestGetOpenAPI(t *testing.T) {
+	t.Parallel()
+	// Test document
+	spec := loadOpenAPI(t)
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Errorf("Expected an OpenAPI 3 document, got version %q", spec.OpenAPI)
+	}
+	if len(spec.Paths) == 0 {
+		t.Error("Expected the document to describe paths")
+	}
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	t.Parallel()
+	// Setup
+	spec := loadOpenAPI(t)
+	r := gin.New()
+	NewServer(failingStore{}, config.Default()).RegisterRoutes(r)
+
+	param := regexp.MustCompile(`:(\w+)`)
+	var routes []string
+	for _, route := range r.Routes() {
+		path := param.ReplaceAllString(route.Path, "{$1}")
+		routes = append(routes, route.Method+" "+path)
+	}
+	var documented []string
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	// Test every route is documented
+	for _, route := range routes {
+		if !slices.Contains(documented, route) {
+			t.Errorf("Route %s is missing from openapi.yaml", route)
+		}
+	}
+
+	// Test every documented operation is routed
+	for _, operation := range documented {
+		if !slices.Contains(routes, operation) {
+			t.Errorf("openapi.yaml documents %s, which is not routed", operation)
+		}
+	}
+}
+
+func TestOpenAPISchemas(t *testing.T) {
+	t.Parallel()
+	// Setup
+	spec := loadOpenAPI(t)
+
+	// Test every schema is checked against Go
+	for name := range spec.Components.Schemas {
+		_, isModel := schemaModels[name]
+		_, isEnum := schemaEnums[name]
+		if !isModel && !isEnum && !slices.Contains(untypedSchemas, name) {
+			t.Errorf("Schema %s is not mapped to a Go type", name)
+		}
+	}
+
+	// Test model properties
+	for name, model := range schemaModels {
+		schema, ok := spec.Components.Schemas[name]
+		if !ok {
+			t.Errorf("Schema %s is missing from openapi.yaml", name)
+			continue
+		}
+		got := spec.properties(t, schema)
+		want := jsonFields(reflect.TypeOf(model))
+		if !slices.Equal(got, want) {
+			t.Errorf("Schema %s has properties %v, %T has %v", name, got, model, want)
+		}
+	}
+
+	// Test enum values
+	for name, values := range schemaEnums {
+		schema, ok := spec.Components.Schemas[name]
+		if !ok {
+			t.Errorf("Schema %s is missing from openapi.yaml", name)
+			continue
+		}
+		got, want := slices.Clone(schema.Enum), slices.Clone(values)
+		sort.Strings(got)
+		sort.Strings(want)
+		if !slices.Equal(got, want) {
+			t.Errorf("Schema %s has values %v, Go accepts %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/gin-gonic/gin"
+)
+
+// invalidID writes a problem for a path parameter that is not an integer ID
+func invalidID(c *gin.Context, param, detail string) {
+	problem.Abort(c, problem.InvalidParameter, detail, problem.Field(param, "must be an integer"))
+}
+
+// invalidPagination writes a problem for a malformed limit or cursor
+func invalidPagination(c *gin.Context, err error) {
+	field := "cursor"
+	if errors.Is(err, pagination.ErrInvalidLimit) {
+		field = "limit"
+	}
+	problem.Abort(c, problem.InvalidParameter, "Invalid pagination", problem.Field(field, err.Error()))
+}
+
+// internalError logs err and writes a problem that does not reveal it
+func internalError(c *gin.Context, err error, detail string) {
+	slog.ErrorContext(c.Request.Context(), detail, "method", c.Request.Method, "path", c.Request.URL.Path, "err", err)
+	problem.Abort(c, problem.Internal, detail)
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/quiz"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
+	"github.com/gin-gonic/gin"
+)
+
+// GetQuiz returns multiple choice questions about the words in a study session's group
+func (s *Server) GetQuiz(c *gin.Context) {
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid session ID")
+		return
+	}
+
+	questionType, ok := quiz.ParseType(c.DefaultQuery("type", string(quiz.EnglishToSpanish)))
+	if !ok {
+		problem.Abort(c, problem.InvalidParameter, "Invalid question type", problem.Field("type", "is not a known question type"))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		problem.Abort(c, problem.InvalidParameter, "Invalid limit", problem.Field("limit", "must be between 1 and 100"))
+		return
+	}
+
+	// Get session details
+	session, err := s.store.FindStudySession(c.Request.Context(), sessionID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.StudySessionNotFound, "Study session not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to fetch study session")
+		return
+	}
+
+	// Get words for the group along with their first image
+	words, err := s.store.ListQuizWords(c.Request.Context(), session.GroupID)
+	if err != nil {
+		internalError(c, err, "Failed to fetch quiz words")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Quiz{
+		SessionID: sessionID,
+		Type:      questionType,
+		Questions: quiz.Build(questionType, words, limit),
+	})
+}
+
+// CreateQuizAnswer grades a quiz answer and records it as a word review
+func (s *Server) CreateQuizAnswer(c *gin.Context) {
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid session ID")
+		return
+	}
+
+	// Parse request body
+	var request struct {
+		WordID       int     `json:"word_id" binding:"required,gt=0"`
+		Type         string  `json:"type" binding:"required,question_type"`
+		Answer       string  `json:"answer"`
+		ResponseTime float64 `json:"response_time" binding:"required,gt=0"`
+	}
+	if !bindJSON(c, &request) {
+		return
+	}
+	questionType, _ := quiz.ParseType(request.Type)
+
+	// Check the word exists before looking for it in the session's group
+	_, err = s.store.FindWord(c.Request.Context(), request.WordID)
+	if errors.Is(err, store.ErrNotFound) {
+		referenceError(c, problem.Field("word_id", "word does not exist"))
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to fetch word")
+		return
+	}
+
+	// Get session and the word, making sure it belongs to the session's group
+	session, word, ok := s.findSessionWord(c, sessionID, request.WordID)
+	if !ok {
+		return
+	}
+
+	// Grade the answer and record it
+	expected := quiz.Expected(questionType, quiz.Word{ID: word.ID, English: word.English, Spanish: word.Spanish})
+	correct := quiz.Check(expected, request.Answer)
+
+	masteryLevel, err := s.store.RecordReview(c.Request.Context(), word.ID, session.StudyActivityID, correct, request.ResponseTime)
+	if err != nil {
+		internalError(c, err, "Failed to create word review")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.QuizAnswerResult{
+		WordReviewResult: models.WordReviewResult{
+			WordID:          word.ID,
+			SessionID:       sessionID,
+			Correct:         correct,
+			ResponseTime:    request.ResponseTime,
+			NewMasteryLevel: masteryLevel,
+		},
+		Type:     questionType,
+		Answer:   request.Answer,
+		Expected: expected,
+	})
+}
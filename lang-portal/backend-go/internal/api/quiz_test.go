@@ -0,0 +1,157 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/color"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
+)
+
+func TestGetQuiz(t *testing.T) {
+	// Setup
+	s, _ := newTestServer(t)
+
+	r := testutil.SetupTestRouter()
+	r.POST("/api/words/:id/images", s.CreateWordImage)
+	r.GET("/api/study_sessions/:id/quiz", s.GetQuiz)
+
+	// Test translation questions for the session's group
+	w := testutil.MakeRequest(r, "GET", "/api/study_sessions/1/quiz", nil)
+	testutil.AssertStatus(t, w, 200)
+
+	var response struct {
+		Type      string `json:"type"`
+		Questions []struct {
+			WordID   int      `json:"word_id"`
+			Prompt   string   `json:"prompt"`
+			ImageURL string   `json:"image_url"`
+			Choices  []string `json:"choices"`
+		} `json:"questions"`
+	}
+	testutil.ParseResponse(t, w, &response)
+	if response.Type != "english_to_spanish" {
+		t.Errorf("Expected default type english_to_spanish, got %s", response.Type)
+	}
+	if len(response.Questions) != 2 {
+		t.Errorf("Expected 2 questions, got %d", len(response.Questions))
+	}
+
+	// Test picture questions only include words with images
+	w = testutil.MakeRequest(r, "GET", "/api/study_sessions/1/quiz?type=picture_to_word", nil)
+	testutil.AssertStatus(t, w, 200)
+	testutil.ParseResponse(t, w, &response)
+	if len(response.Questions) != 0 {
+		t.Errorf("Expected no picture questions without images, got %d", len(response.Questions))
+	}
+
+	w = testutil.MakeMultipartRequest(t, r, "POST", "/api/words/2/images", "file", "goodbye.png", testPNG(t, 10, 10, color.White))
+	testutil.AssertStatus(t, w, 201)
+
+	w = testutil.MakeRequest(r, "GET", "/api/study_sessions/1/quiz?type=picture_to_word", nil)
+	testutil.AssertStatus(t, w, 200)
+	testutil.ParseResponse(t, w, &response)
+	if len(response.Questions) != 1 {
+		t.Fatalf("Expected 1 picture question, got %d", len(response.Questions))
+	}
+	question := response.Questions[0]
+	if question.WordID != 2 || question.ImageURL != "/api/images/1" || question.Prompt != "" {
+		t.Errorf("Unexpected picture question: %+v", question)
+	}
+	if len(question.Choices) != 2 {
+		t.Errorf("Expected 2 choices from a 2-word group, got %v", question.Choices)
+	}
+
+	// Test invalid type
+	w = testutil.MakeRequest(r, "GET", "/api/study_sessions/1/quiz?type=essay", nil)
+	testutil.AssertStatus(t, w, 400)
+
+	// Test non-existent session
+	w = testutil.MakeRequest(r, "GET", "/api/study_sessions/999/quiz", nil)
+	testutil.AssertStatus(t, w, 404)
+}
+
+func TestCreateQuizAnswer(t *testing.T) {
+	// Setup
+	s, _ := newTestServer(t)
+
+	r := testutil.SetupTestRouter()
+	r.POST("/api/study_sessions/:id/quiz/answers", s.CreateQuizAnswer)
+
+	// Test correct picture answer
+	body, _ := json.Marshal(map[string]interface{}{
+		"word_id":       2,
+		"type":          "picture_to_word",
+		"answer":        "Adios",
+		"response_time": 1.5,
+	})
+	w := testutil.MakeRequest(r, "POST", "/api/study_sessions/1/quiz/answers", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 200)
+
+	var response map[string]interface{}
+	testutil.ParseResponse(t, w, &response)
+	if response["correct"] != true || response["expected"] != "adios" {
+		t.Errorf("Expected correct answer 'adios', got %v", response)
+	}
+
+	// Test incorrect reverse translation
+	body, _ = json.Marshal(map[string]interface{}{
+		"word_id":       2,
+		"type":          "spanish_to_english",
+		"answer":        "hello",
+		"response_time": 1.5,
+	})
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/quiz/answers", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 200)
+	testutil.ParseResponse(t, w, &response)
+	if response["correct"] != false || response["expected"] != "goodbye" {
+		t.Errorf("Expected incorrect answer with expected 'goodbye', got %v", response)
+	}
+
+	// Test word outside the session's group
+	body, _ = json.Marshal(map[string]interface{}{
+		"word_id":       3,
+		"type":          "picture_to_word",
+		"answer":        "gracias",
+		"response_time": 1.5,
+	})
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/quiz/answers", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 404)
+
+	// Test invalid type
+	body, _ = json.Marshal(map[string]interface{}{
+		"word_id":       2,
+		"type":          "essay",
+		"answer":        "adios",
+		"response_time": 1.5,
+	})
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/quiz/answers", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 422)
+	p := testutil.AssertProblem(t, w, problem.ValidationFailed)
+	assertFields(t, p, "type")
+
+	// Test missing word
+	body, _ = json.Marshal(map[string]interface{}{
+		"word_id":       999,
+		"type":          "english_to_spanish",
+		"answer":        "adios",
+		"response_time": 1.5,
+	})
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/quiz/answers", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 422)
+	p = testutil.AssertProblem(t, w, problem.ValidationFailed)
+	assertFields(t, p, "word_id")
+
+	// Test response time is required and must be positive
+	for _, body := range []string{
+		`{"word_id": 1, "type": "english_to_spanish", "answer": "hola"}`,
+		`{"word_id": 1, "type": "english_to_spanish", "answer": "hola", "response_time": -1}`,
+	} {
+		w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/quiz/answers", bytes.NewBufferString(body))
+		testutil.AssertStatus(t, w, 422)
+		p = testutil.AssertProblem(t, w, problem.ValidationFailed)
+		assertFields(t, p, "response_time")
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+	"github.com/gin-gonic/gin"
+)
+
+// MaxUploadBytes is the largest multipart body any route accepts: an audio
+// clip, the largest kind of upload, with room for the multipart envelope
+const MaxUploadBytes = media.MaxAudioSize + 1<<20
+
+// RegisterRoutes adds every API route to r. openapi.yaml describes the same
+// routes; TestOpenAPIRoutes fails when the two disagree.
+func (s *Server) RegisterRoutes(r gin.IRoutes) {
+	// API description
+	r.GET("/api/openapi.json", s.GetOpenAPI)
+
+	// Dashboard routes
+	r.GET("/api/dashboard/last_study_session", s.GetLastStudySession)
+	r.GET("/api/dashboard/study_progress", s.GetStudyProgress)
+	r.GET("/api/dashboard/quick_stats", s.GetQuickStats)
+
+	// Study activities routes
+	r.GET("/api/study_activities/:id", s.GetStudyActivity)
+	r.GET("/api/study_activities/:id/study_sessions", s.GetStudyActivitySessions)
+	r.POST("/api/study_activities", s.CreateStudyActivity)
+
+	// Words routes
+	r.GET("/api/words", s.GetWords)
+	r.GET("/api/words/:id", s.GetWord)
+	r.DELETE("/api/words/:id", s.DeleteWord)
+	r.GET("/api/words/:id/conjugations", s.GetWordConjugations)
+	r.GET("/api/words/:id/conjugations/drill", s.GetConjugationDrill)
+	r.POST("/api/words/:id/audio", s.CreateWordAudio)
+	r.GET("/api/words/:id/audio/:audio_id", s.GetWordAudio)
+	r.DELETE("/api/words/:id/audio/:audio_id", s.DeleteWordAudio)
+	r.POST("/api/words/:id/images", s.CreateWordImage)
+	r.DELETE("/api/words/:id/images/:image_id", s.DeleteWordImage)
+	r.POST("/api/words/:id/tags", s.AddWordTags)
+	r.DELETE("/api/words/:id/tags/:tag_id", s.DeleteWordTag)
+
+	// Tags routes
+	r.GET("/api/tags", s.GetTags)
+	r.POST("/api/tags", s.CreateTag)
+	r.PUT("/api/tags/:id", s.UpdateTag)
+	r.DELETE("/api/tags/:id", s.DeleteTag)
+
+	// Images routes
+	r.GET("/api/images/:id", s.GetImage)
+	r.GET("/api/images/:id/thumbnail", s.GetImageThumbnail)
+
+	// Groups routes
+	r.GET("/api/groups", s.GetGroups)
+	r.GET("/api/groups/:id", s.GetGroup)
+	r.GET("/api/groups/:id/words", s.GetGroupWords)
+	r.GET("/api/groups/:id/study_sessions", s.GetGroupStudySessions)
+	r.POST("/api/groups", s.CreateGroup)
+	r.POST("/api/groups/from_tags", s.CreateGroupFromTags)
+
+	// Study sessions routes
+	r.GET("/api/study_sessions", s.GetStudySessions)
+	r.POST("/api/study_sessions", s.CreateStudySession)
+	r.GET("/api/study_sessions/:id", s.GetStudySession)
+	r.GET("/api/study_sessions/:id/words", s.GetStudySessionWords)
+	r.POST("/api/study_sessions/:id/words/:word_id/review", s.CreateWordReview)
+	r.POST("/api/study_sessions/:id/words/:word_id/conjugation_drill", s.CreateConjugationDrillReview)
+	r.GET("/api/study_sessions/:id/quiz", s.GetQuiz)
+	r.POST("/api/study_sessions/:id/quiz/answers", s.CreateQuizAnswer)
+
+	// System management routes
+	r.POST("/api/reset_history", s.ResetHistory)
+	r.POST("/api/full_reset", s.FullReset)
+	r.GET("/api/system/config", s.GetConfig)
+	r.GET("/api/system/backups", s.GetBackups)
+	r.POST("/api/system/backups", s.CreateBackup)
+	r.GET("/api/system/backups/:name", s.DownloadBackup)
+	r.POST("/api/system/backups/:name/restore", s.RestoreBackup)
+}
+
+// IsAdminPath reports whether path is one of the system management routes,
+// which reset data or manage backups
+func IsAdminPath(path string) bool {
+	return path == "/api/reset_history" || path == "/api/full_reset" || strings.HasPrefix(path, "/api/system/")
+}
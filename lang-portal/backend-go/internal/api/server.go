@@ -0,0 +1,28 @@
+package api
+
+import (
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/backup"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/config"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
+)
+
+// Server holds the dependencies shared by the API handlers
+type Server struct {
+	store   store.Store
+	config  *config.Config
+	media   media.Dir
+	backups *backup.Dir
+}
+
+// NewServer returns a Server whose handlers read and write through st, and
+// serve media and keep backups in the directories cfg names. st should keep
+// its media in the same directory.
+func NewServer(st store.Store, cfg *config.Config) *Server {
+	return &Server{
+		store:   st,
+		config:  cfg,
+		media:   media.Dir(cfg.MediaDir),
+		backups: backup.NewDir(cfg.BackupDir, cfg.BackupRetention),
+	}
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/config"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
+	"github.com/gin-gonic/gin"
+)
+
+// testConfig returns the default configuration, with media and backups kept
+// in temporary directories
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+	cfg := config.Default()
+	cfg.MediaDir = t.TempDir()
+	cfg.BackupDir = t.TempDir()
+	return cfg
+}
+
+// newTestServer returns a Server backed by a seeded test database, along with
+// the database connection for direct inspection
+func newTestServer(t *testing.T) (*Server, *sql.DB) {
+	t.Helper()
+	return newTestServerWith(t, testConfig(t))
+}
+
+// newTestServerWith returns a Server like newTestServer's running with cfg
+func newTestServerWith(t *testing.T, cfg *config.Config) (*Server, *sql.DB) {
+	t.Helper()
+	conn := testutil.NewTestDB(t)
+	return NewServer(store.NewSQLite(conn, media.Dir(cfg.MediaDir)), cfg), conn
+}
+
+// assertFields checks that a problem lists exactly the given invalid fields, in order
+func assertFields(t *testing.T, p *problem.Problem, fields ...string) {
+	t.Helper()
+	var got []string
+	for _, e := range p.Errors {
+		got = append(got, e.Field)
+	}
+	if !slices.Equal(got, fields) {
+		t.Errorf("Expected invalid fields %v, got %+v", fields, p.Errors)
+	}
+}
+
+// failingStore is a Store whose methods fail, for exercising error paths
+// without breaking a real database
+type failingStore struct {
+	store.Store
+}
+
+func (failingStore) QuickStats(context.Context) (*models.QuickStats, error) {
+	return nil, errors.New("database unavailable")
+}
+
+func (failingStore) GetStudySession(context.Context, int) (*models.StudySessionWithStats, error) {
+	return nil, store.ErrNotFound
+}
+
+func TestServerStoreErrors(t *testing.T) {
+	t.Parallel()
+	// Setup
+	s := NewServer(failingStore{}, config.Default())
+	r := testutil.SetupTestRouter()
+	r.GET("/api/dashboard/quick_stats", s.GetQuickStats)
+	r.GET("/api/study_sessions/:id", s.GetStudySession)
+
+	// Test store failure
+	w := testutil.MakeRequest(r, "GET", "/api/dashboard/quick_stats", nil)
+	testutil.AssertStatus(t, w, 500)
+	testutil.AssertProblem(t, w, problem.Internal)
+
+	// Test missing record
+	w = testutil.MakeRequest(r, "GET", "/api/study_sessions/1", nil)
+	testutil.AssertStatus(t, w, 404)
+	p := testutil.AssertProblem(t, w, problem.StudySessionNotFound)
+	if p.Instance != "/api/study_sessions/1" || p.Type != "urn:langportal:problem:study_session_not_found" {
+		t.Errorf("Unexpected problem %+v", p)
+	}
+}
+
+func TestBindJSONTooLarge(t *testing.T) {
+	t.Parallel()
+	// Setup a router capping bodies the way limits.BodySize does
+	s, _ := newTestServer(t)
+	r := testutil.SetupTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, 16)
+	})
+	r.POST("/api/tags", s.CreateTag)
+
+	// Test
+	w := testutil.MakeRequest(r, "POST", "/api/tags", strings.NewReader(`{"name": "`+strings.Repeat("a", 32)+`"}`))
+	testutil.AssertStatus(t, w, 413)
+	p := testutil.AssertProblem(t, w, problem.BodyTooLarge)
+	if !strings.Contains(p.Detail, "16 bytes") {
+		t.Errorf("Expected the limit in the detail, got %q", p.Detail)
+	}
+}
+
+func TestServersIndependent(t *testing.T) {
+	// Test servers running side by side keep to their own settings and media
+	for _, pack := range []string{"initial_data", "none"} {
+		pack := pack
+		t.Run(pack, func(t *testing.T) {
+			t.Parallel()
+			// Setup
+			cfg := testConfig(t)
+			cfg.SeedPack = pack
+			s, _ := newTestServerWith(t, cfg)
+			r := testutil.SetupTestRouter()
+			r.GET("/api/system/config", s.GetConfig)
+			r.POST("/api/words/:id/audio", s.CreateWordAudio)
+
+			// Test
+			w := testutil.MakeRequest(r, "GET", "/api/system/config", nil)
+			var got config.Config
+			testutil.ParseResponse(t, w, &got)
+			if got.SeedPack != pack || got.MediaDir != cfg.MediaDir {
+				t.Errorf("Expected the server's own configuration, got %+v", got)
+			}
+			w = testutil.MakeMultipartRequest(t, r, "POST", "/api/words/1/audio", "file", "hola.wav", testWAV)
+			testutil.AssertStatus(t, w, http.StatusCreated)
+			if files, _ := filepath.Glob(filepath.Join(cfg.MediaDir, "audio", "1", "*")); len(files) != 1 {
+				t.Errorf("Expected the clip in the server's media directory, found %v", files)
+			}
+		})
+	}
+}
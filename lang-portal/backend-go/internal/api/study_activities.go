@@ -1,214 +1,96 @@
 package api
 
 import (
-	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 
-	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
-	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
 	"github.com/gin-gonic/gin"
 )
 
 // GetStudyActivity returns details for a specific study activity
-func GetStudyActivity(c *gin.Context) {
-	db := db.GetDB()
-
+func (s *Server) GetStudyActivity(c *gin.Context) {
 	activityID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		invalidID(c, "id", "Invalid activity ID")
 		return
 	}
 
-	// Get activity details
-	var activity models.StudyActivityWithStats
-	err = db.QueryRow(`
-		SELECT 
-			sa.id,
-			sa.name,
-			sa.description,
-			sa.created_at,
-			sa.updated_at,
-			COUNT(DISTINCT ss.id) as total_sessions
-		FROM study_activities sa
-		LEFT JOIN study_sessions ss ON sa.id = ss.study_activity_id
-		WHERE sa.id = ?
-		GROUP BY sa.id
-	`, activityID).Scan(
-		&activity.ID,
-		&activity.Name,
-		&activity.Description,
-		&activity.CreatedAt,
-		&activity.UpdatedAt,
-		&activity.TotalSessions,
-	)
-
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Study activity not found"})
+	// Get activity details and associated group IDs
+	activity, err := s.store.GetStudyActivity(c.Request.Context(), activityID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.StudyActivityNotFound, "Study activity not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch study activity"})
-		return
-	}
-
-	// Get associated group IDs
-	rows, err := db.Query(`
-		SELECT group_id
-		FROM study_activity_groups
-		WHERE study_activity_id = ?
-	`, activityID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch activity groups"})
+		internalError(c, err, "Failed to fetch study activity")
 		return
 	}
-	defer rows.Close()
 
-	var groupIDs []int
-	for rows.Next() {
-		var groupID int
-		if err := rows.Scan(&groupID); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan group ID"})
-			return
-		}
-		groupIDs = append(groupIDs, groupID)
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"id":             activity.ID,
-		"name":           activity.Name,
-		"description":    activity.Description,
-		"created_at":     activity.CreatedAt,
-		"updated_at":     activity.UpdatedAt,
-		"total_sessions": activity.TotalSessions,
-		"group_ids":      groupIDs,
-	})
+	c.JSON(http.StatusOK, activity)
 }
 
-// GetStudyActivitySessions returns all study sessions for a specific activity
-func GetStudyActivitySessions(c *gin.Context) {
-	db := db.GetDB()
-
+// GetStudyActivitySessions returns a paginated list of the study sessions for a specific activity
+func (s *Server) GetStudyActivitySessions(c *gin.Context) {
 	activityID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		invalidID(c, "id", "Invalid activity ID")
 		return
 	}
 
-	rows, err := db.Query(`
-		SELECT 
-			ss.id,
-			sa.name as activity_name,
-			g.name as group_name,
-			ss.created_at as start_time,
-			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_activity_id = ss.study_activity_id) as review_items_count,
-			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_activity_id = ss.study_activity_id AND wri.correct = 1) as correct_count,
-			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_activity_id = ss.study_activity_id AND wri.correct = 0) as incorrect_count
-		FROM study_sessions ss
-		JOIN study_activities sa ON ss.study_activity_id = sa.id
-		JOIN groups g ON ss.group_id = g.id
-		WHERE ss.study_activity_id = ?
-		ORDER BY ss.created_at DESC
-	`, activityID)
+	// Parse pagination parameters
+	page, err := pagination.Parse(c, sessionOrder, 2)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch activity study sessions"})
+		invalidPagination(c, err)
 		return
 	}
-	defer rows.Close()
-
-	var sessions []models.StudySessionWithStats
-	for rows.Next() {
-		var session models.StudySessionWithStats
-		err := rows.Scan(
-			&session.ID,
-			&session.ActivityName,
-			&session.GroupName,
-			&session.StartTime,
-			&session.ReviewItemsCount,
-			&session.CorrectCount,
-			&session.IncorrectCount,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan study session"})
-			return
-		}
-
-		// Calculate score as percentage
-		if session.ReviewItemsCount > 0 {
-			session.Score = (session.CorrectCount * 100) / session.ReviewItemsCount
-		}
 
-		sessions = append(sessions, session)
+	sessions, err := s.store.ListStudySessions(c.Request.Context(), store.SessionFilter{ActivityID: activityID}, page)
+	if err != nil {
+		internalError(c, err, "Failed to fetch activity study sessions")
+		return
 	}
 
-	c.JSON(http.StatusOK, sessions)
+	pagination.Respond(c, page, sessions, sessionKey)
 }
 
 // CreateStudyActivity creates a new study activity
-func CreateStudyActivity(c *gin.Context) {
-	db := db.GetDB()
-
+func (s *Server) CreateStudyActivity(c *gin.Context) {
 	// Parse request
 	var request struct {
-		Name        string `json:"name"`
+		Name        string `json:"name" binding:"required,notblank"`
 		Description string `json:"description"`
-		GroupIDs    []int  `json:"group_ids"`
+		GroupIDs    []int  `json:"group_ids" binding:"required,min=1,unique,dive,gt=0"`
 	}
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	if !bindJSON(c, &request) {
 		return
 	}
 
-	// Start transaction
-	tx, err := db.Begin()
+	// Check every group exists before writing anything
+	missing, err := s.store.MissingGroups(c.Request.Context(), request.GroupIDs)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		internalError(c, err, "Failed to check groups")
 		return
 	}
-
-	// Create study activity
-	result, err := tx.Exec(`
-		INSERT INTO study_activities (name, description)
-		VALUES (?, ?)
-	`, request.Name, request.Description)
-	if err != nil {
-		if err := tx.Rollback(); err != nil {
-			fmt.Printf("Error rolling back transaction: %v\n", err)
+	if len(missing) > 0 {
+		var fields []problem.FieldError
+		for i, groupID := range request.GroupIDs {
+			if slices.Contains(missing, groupID) {
+				fields = append(fields, problem.Field(fmt.Sprintf("group_ids[%d]", i), "group does not exist"))
+			}
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create study activity"})
+		referenceError(c, fields...)
 		return
 	}
 
-	activityID, err := result.LastInsertId()
+	// Create study activity linked to its groups
+	activityID, err := s.store.CreateStudyActivity(c.Request.Context(), request.Name, request.Description, request.GroupIDs)
 	if err != nil {
-		if err := tx.Rollback(); err != nil {
-			fmt.Printf("Error rolling back transaction: %v\n", err)
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get created activity ID"})
-		return
-	}
-
-	// Link activity to groups
-	for _, groupID := range request.GroupIDs {
-		_, err = tx.Exec(`
-			INSERT INTO study_activity_groups (study_activity_id, group_id)
-			VALUES (?, ?)
-		`, activityID, groupID)
-		if err != nil {
-			if err := tx.Rollback(); err != nil {
-				fmt.Printf("Error rolling back transaction: %v\n", err)
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link activity to group"})
-			return
-		}
-	}
-
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		if err := tx.Rollback(); err != nil {
-			fmt.Printf("Error rolling back transaction: %v\n", err)
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction"})
+		internalError(c, err, "Failed to create study activity")
 		return
 	}
 
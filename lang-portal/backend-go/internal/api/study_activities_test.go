@@ -6,16 +6,17 @@ import (
 	"testing"
 
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
 )
 
 func TestGetStudyActivity(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, _ := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/study_activities/:id", GetStudyActivity)
+	r.GET("/api/study_activities/:id", s.GetStudyActivity)
 
 	// Test existing activity
 	w := testutil.MakeRequest(r, "GET", "/api/study_activities/1", nil)
@@ -37,19 +38,19 @@ func TestGetStudyActivity(t *testing.T) {
 }
 
 func TestGetStudyActivitySessions(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, _ := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/study_activities/:id/study_sessions", GetStudyActivitySessions)
+	r.GET("/api/study_activities/:id/study_sessions", s.GetStudyActivitySessions)
 
 	// Test
 	w := testutil.MakeRequest(r, "GET", "/api/study_activities/1/study_sessions", nil)
 	testutil.AssertStatus(t, w, 200)
 
 	var response []map[string]interface{}
-	testutil.ParseResponse(t, w, &response)
+	testutil.ParseItems(t, w, &response)
 
 	if len(response) != 1 {
 		t.Errorf("Expected 1 study session, got %d", len(response))
@@ -63,17 +64,22 @@ func TestGetStudyActivitySessions(t *testing.T) {
 }
 
 func TestCreateStudyActivity(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, conn := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.POST("/api/study_activities", CreateStudyActivity)
+	r.POST("/api/study_activities", s.CreateStudyActivity)
 
 	// Create test activity data
-	activity := models.StudyActivity{
+	activity := struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		GroupIDs    []int  `json:"group_ids"`
+	}{
 		Name:        "New Activity",
 		Description: "Test description",
+		GroupIDs:    []int{1},
 	}
 	body, err := json.Marshal(activity)
 	if err != nil {
@@ -100,4 +106,21 @@ func TestCreateStudyActivity(t *testing.T) {
 	// Test invalid request
 	w = testutil.MakeRequest(r, "POST", "/api/study_activities", bytes.NewBufferString("invalid json"))
 	testutil.AssertStatus(t, w, 400)
+	testutil.AssertProblem(t, w, problem.InvalidBody)
+
+	// Test every invalid field is listed
+	w = testutil.MakeRequest(r, "POST", "/api/study_activities", bytes.NewBufferString(`{"name": " ", "group_ids": []}`))
+	testutil.AssertStatus(t, w, 422)
+	p := testutil.AssertProblem(t, w, problem.ValidationFailed)
+	assertFields(t, p, "name", "group_ids")
+
+	// Test missing groups are rejected before anything is written
+	w = testutil.MakeRequest(r, "POST", "/api/study_activities", bytes.NewBufferString(`{"name": "Orphan", "group_ids": [1, 998, 999]}`))
+	testutil.AssertStatus(t, w, 422)
+	p = testutil.AssertProblem(t, w, problem.ValidationFailed)
+	assertFields(t, p, "group_ids[1]", "group_ids[2]")
+	var count int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM study_activities WHERE name = 'Orphan'").Scan(&count); err != nil || count != 0 {
+		t.Errorf("Expected no activity to be created, found %d (%v)", count, err)
+	}
 }
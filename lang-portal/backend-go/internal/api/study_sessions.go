@@ -1,374 +1,168 @@
 package api
 
 import (
-	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
-	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
 	"github.com/gin-gonic/gin"
 )
 
 // CreateStudySession creates a new study session
-func CreateStudySession(c *gin.Context) {
-	db := db.GetDB()
-
+func (s *Server) CreateStudySession(c *gin.Context) {
 	// Parse request body
 	var request struct {
-		StudyActivityID int       `json:"study_activity_id"`
-		StartTime       time.Time `json:"start_time"`
+		StudyActivityID int       `json:"study_activity_id" binding:"required,gt=0"`
+		StartTime       time.Time `json:"start_time" binding:"required"`
 	}
-
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	if !bindJSON(c, &request) {
 		return
 	}
 
-	// Check if study activity exists and get its groups
-	rows, err := db.Query(`
-		SELECT group_id
-		FROM study_activity_groups
-		WHERE study_activity_id = ?
-	`, request.StudyActivityID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch study activity groups"})
+	// Check the activity exists and has a group to study
+	activity, err := s.store.FindStudyActivity(c.Request.Context(), request.StudyActivityID)
+	if errors.Is(err, store.ErrNotFound) {
+		referenceError(c, problem.Field("study_activity_id", "study activity does not exist"))
 		return
-	}
-	defer rows.Close()
-
-	var groupIDs []int
-	for rows.Next() {
-		var groupID int
-		if err := rows.Scan(&groupID); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan group ID"})
-			return
-		}
-		groupIDs = append(groupIDs, groupID)
-	}
-
-	if len(groupIDs) == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Study activity not found or has no groups"})
+	} else if err != nil {
+		internalError(c, err, "Failed to fetch study activity")
 		return
 	}
-
-	// Create study session for the first group
-	result, err := db.Exec(`
-		INSERT INTO study_sessions (study_activity_id, group_id, created_at)
-		VALUES (?, ?, ?)
-	`, request.StudyActivityID, groupIDs[0], request.StartTime)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create study session"})
+	if len(activity.GroupIDs) == 0 {
+		referenceError(c, problem.Field("study_activity_id", "study activity has no groups"))
 		return
 	}
 
-	// Get the ID of the created session
-	sessionID, err := result.LastInsertId()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get session ID"})
+	// Create study session for the activity's first group
+	session, err := s.store.CreateStudySession(c.Request.Context(), request.StudyActivityID, request.StartTime)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.StudyActivityNotFound, "Study activity not found or has no groups")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to create study session")
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"id":                sessionID,
-		"study_activity_id": request.StudyActivityID,
-		"group_id":          groupIDs[0],
-		"start_time":        request.StartTime,
+		"id":                session.ID,
+		"study_activity_id": session.StudyActivityID,
+		"group_id":          session.GroupID,
+		"start_time":        session.CreatedAt,
 	})
 }
 
-// GetStudySessions returns a list of all study sessions with pagination
-func GetStudySessions(c *gin.Context) {
-	db := db.GetDB()
-
+// GetStudySessions returns a paginated list of study sessions, most recent first
+func (s *Server) GetStudySessions(c *gin.Context) {
 	// Parse pagination parameters
-	limit := 20 // default limit
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
-			limit = parsedLimit
-		}
-	}
-
-	offset := 0
-	if offsetStr := c.Query("offset"); offsetStr != "" {
-		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
-			offset = parsedOffset
-		}
-	}
-
-	rows, err := db.Query(`
-		SELECT 
-			ss.id,
-			sa.name as activity_name,
-			g.name as group_name,
-			ss.created_at as start_time,
-			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_activity_id = ss.study_activity_id) as review_items_count,
-			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_activity_id = ss.study_activity_id AND wri.correct = 1) as correct_count,
-			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_activity_id = ss.study_activity_id AND wri.correct = 0) as incorrect_count
-		FROM study_sessions ss
-		JOIN study_activities sa ON ss.study_activity_id = sa.id
-		JOIN groups g ON ss.group_id = g.id
-		ORDER BY ss.created_at DESC
-		LIMIT ? OFFSET ?
-	`, limit, offset)
+	page, err := pagination.Parse(c, sessionOrder, 2)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch study sessions"})
+		invalidPagination(c, err)
 		return
 	}
-	defer rows.Close()
-
-	var sessions []models.StudySessionWithStats
-	for rows.Next() {
-		var session models.StudySessionWithStats
-		err := rows.Scan(
-			&session.ID,
-			&session.ActivityName,
-			&session.GroupName,
-			&session.StartTime,
-			&session.ReviewItemsCount,
-			&session.CorrectCount,
-			&session.IncorrectCount,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan study session"})
-			return
-		}
-
-		// Calculate score as percentage
-		if session.ReviewItemsCount > 0 {
-			session.Score = (session.CorrectCount * 100) / session.ReviewItemsCount
-		}
 
-		// Set end time to 30 minutes after start time
-		session.EndTime = session.StartTime.Add(30 * time.Minute)
-
-		sessions = append(sessions, session)
+	sessions, err := s.store.ListStudySessions(c.Request.Context(), store.SessionFilter{}, page)
+	if err != nil {
+		internalError(c, err, "Failed to fetch study sessions")
+		return
 	}
 
-	c.JSON(http.StatusOK, sessions)
+	pagination.Respond(c, page, sessions, sessionKey)
 }
 
 // GetStudySession returns details for a specific study session
-func GetStudySession(c *gin.Context) {
-	db := db.GetDB()
-
+func (s *Server) GetStudySession(c *gin.Context) {
 	sessionID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		invalidID(c, "id", "Invalid session ID")
 		return
 	}
 
-	var session models.StudySessionWithStats
-	err = db.QueryRow(`
-		SELECT 
-			ss.id,
-			sa.name as activity_name,
-			g.name as group_name,
-			ss.created_at as start_time,
-			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_activity_id = ss.study_activity_id) as review_items_count,
-			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_activity_id = ss.study_activity_id AND wri.correct = 1) as correct_count,
-			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_activity_id = ss.study_activity_id AND wri.correct = 0) as incorrect_count
-		FROM study_sessions ss
-		JOIN study_activities sa ON ss.study_activity_id = sa.id
-		JOIN groups g ON ss.group_id = g.id
-		WHERE ss.id = ?
-	`, sessionID).Scan(
-		&session.ID,
-		&session.ActivityName,
-		&session.GroupName,
-		&session.StartTime,
-		&session.ReviewItemsCount,
-		&session.CorrectCount,
-		&session.IncorrectCount,
-	)
-
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Study session not found"})
+	session, err := s.store.GetStudySession(c.Request.Context(), sessionID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.StudySessionNotFound, "Study session not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch study session"})
+		internalError(c, err, "Failed to fetch study session")
 		return
 	}
 
-	// Calculate score as percentage
-	if session.ReviewItemsCount > 0 {
-		session.Score = (session.CorrectCount * 100) / session.ReviewItemsCount
-	}
-
-	// Set end time to 30 minutes after start time
-	session.EndTime = session.StartTime.Add(30 * time.Minute)
-
 	c.JSON(http.StatusOK, session)
 }
 
-// GetStudySessionWords returns all words reviewed in a specific study session
-func GetStudySessionWords(c *gin.Context) {
-	db := db.GetDB()
-
+// GetStudySessionWords returns a paginated list of the words in a study session's group
+func (s *Server) GetStudySessionWords(c *gin.Context) {
 	sessionID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		invalidID(c, "id", "Invalid session ID")
 		return
 	}
 
 	// Get session details
-	var studyActivityID, groupID int
-	err = db.QueryRow(`
-		SELECT study_activity_id, group_id
-		FROM study_sessions
-		WHERE id = ?
-	`, sessionID).Scan(&studyActivityID, &groupID)
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Study session not found"})
+	session, err := s.store.FindStudySession(c.Request.Context(), sessionID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.StudySessionNotFound, "Study session not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch session"})
+		internalError(c, err, "Failed to fetch session")
 		return
 	}
 
-	// Get words for the group
-	rows, err := db.Query(`
-		SELECT 
-			w.id,
-			w.english,
-			w.spanish,
-			w.level,
-			w.created_at,
-			w.updated_at,
-			COUNT(CASE WHEN wri.correct = 1 THEN 1 END) as correct_count,
-			COUNT(CASE WHEN wri.correct = 0 THEN 1 END) as incorrect_count,
-			COALESCE(AVG(CASE WHEN wri.correct THEN 1.0 ELSE 0.0 END), 0) as mastery_level
-		FROM words w
-		JOIN word_groups wg ON w.id = wg.word_id
-		LEFT JOIN word_review_items wri ON w.id = wri.word_id AND wri.study_activity_id = ?
-		WHERE wg.group_id = ?
-		GROUP BY w.id
-		ORDER BY w.english
-	`, studyActivityID, groupID)
+	// Parse pagination parameters
+	page, err := pagination.Parse(c, "english:asc", 2)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch session words"})
+		invalidPagination(c, err)
 		return
 	}
-	defer rows.Close()
 
-	var words []models.WordWithStats
-	for rows.Next() {
-		var word models.WordWithStats
-		err := rows.Scan(
-			&word.ID,
-			&word.English,
-			&word.Spanish,
-			&word.Level,
-			&word.CreatedAt,
-			&word.UpdatedAt,
-			&word.CorrectCount,
-			&word.IncorrectCount,
-			&word.MasteryLevel,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan word"})
-			return
-		}
-		words = append(words, word)
+	// Get words for the group
+	words, err := s.store.ListSessionWords(c.Request.Context(), session, page)
+	if err != nil {
+		internalError(c, err, "Failed to fetch session words")
+		return
 	}
 
-	c.JSON(http.StatusOK, words)
+	pagination.Respond(c, page, words, wordEnglishKey)
 }
 
 // CreateWordReview creates a new word review for a study session
-func CreateWordReview(c *gin.Context) {
-	db := db.GetDB()
-
+func (s *Server) CreateWordReview(c *gin.Context) {
 	// Parse parameters
 	sessionID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		invalidID(c, "id", "Invalid session ID")
 		return
 	}
 
 	wordID, err := strconv.Atoi(c.Param("word_id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid word ID"})
-		return
-	}
-
-	// Get session and check if word belongs to the group
-	var studyActivityID, groupID int
-	err = db.QueryRow(`
-		SELECT ss.study_activity_id, ss.group_id
-		FROM study_sessions ss
-		WHERE ss.id = ?
-	`, sessionID).Scan(&studyActivityID, &groupID)
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Study session not found"})
-		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch study session"})
+		invalidID(c, "word_id", "Invalid word ID")
 		return
 	}
 
-	// Check if word exists and belongs to the group
-	var wordExists bool
-	err = db.QueryRow(`
-		SELECT EXISTS(
-			SELECT 1
-			FROM words w
-			JOIN word_groups wg ON w.id = wg.word_id
-			WHERE w.id = ? AND wg.group_id = ?
-		)
-	`, wordID, groupID).Scan(&wordExists)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check word existence"})
-		return
-	}
-	if !wordExists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Word not found or does not belong to the group"})
+	// Get session and the word, making sure it belongs to the session's group
+	session, word, ok := s.findSessionWord(c, sessionID, wordID)
+	if !ok {
 		return
 	}
 
 	// Parse request body
 	var review struct {
-		Correct      bool    `json:"correct"`
-		ResponseTime float64 `json:"response_time"`
-	}
-	if err := c.ShouldBindJSON(&review); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
-		return
-	}
-
-	// Validate response time
-	if review.ResponseTime <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Response time must be positive"})
-		return
+		Correct      *bool   `json:"correct" binding:"required"`
+		ResponseTime float64 `json:"response_time" binding:"required,gt=0"`
 	}
-
-	// Create word review item
-	result, err := db.Exec(`
-		INSERT INTO word_review_items (word_id, study_activity_id, correct, response_time)
-		VALUES (?, ?, ?, ?)
-	`, wordID, studyActivityID, review.Correct, review.ResponseTime)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create word review"})
-		return
-	}
-
-	// Check if the insert was successful
-	if _, err := result.LastInsertId(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create review"})
+	if !bindJSON(c, &review) {
 		return
 	}
 
-	// Calculate new mastery level
-	var masteryLevel float64
-	err = db.QueryRow(`
-		SELECT COALESCE(AVG(CASE WHEN correct THEN 1.0 ELSE 0.0 END), 0) as mastery_level
-		FROM word_review_items
-		WHERE word_id = ?
-	`, wordID).Scan(&masteryLevel)
+	// Create word review item and calculate new mastery level
+	masteryLevel, err := s.store.RecordReview(c.Request.Context(), word.ID, session.StudyActivityID, *review.Correct, review.ResponseTime)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate mastery level"})
+		internalError(c, err, "Failed to create word review")
 		return
 	}
 
@@ -376,7 +170,7 @@ func CreateWordReview(c *gin.Context) {
 	reviewResult := models.WordReviewResult{
 		WordID:          wordID,
 		SessionID:       sessionID,
-		Correct:         review.Correct,
+		Correct:         *review.Correct,
 		ResponseTime:    review.ResponseTime,
 		NewMasteryLevel: masteryLevel,
 	}
@@ -384,10 +178,41 @@ func CreateWordReview(c *gin.Context) {
 	c.JSON(http.StatusOK, reviewResult)
 }
 
-func SetupStudySessionAPI(router *gin.RouterGroup) {
-	router.POST("/study-sessions", CreateStudySession)
-	router.GET("/study-sessions", GetStudySessions)
-	router.GET("/study-sessions/:id", GetStudySession)
-	router.GET("/study-sessions/:id/words", GetStudySessionWords)
-	router.POST("/study-sessions/:id/words/:word_id/reviews", CreateWordReview)
+// findSessionWord looks up a study session and one of the words in its
+// group, writing an error response and returning false if either is missing
+func (s *Server) findSessionWord(c *gin.Context, sessionID, wordID int) (*models.StudySession, *models.Word, bool) {
+	session, err := s.store.FindStudySession(c.Request.Context(), sessionID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.StudySessionNotFound, "Study session not found")
+		return nil, nil, false
+	} else if err != nil {
+		internalError(c, err, "Failed to fetch study session")
+		return nil, nil, false
+	}
+
+	word, err := s.store.FindGroupWord(c.Request.Context(), session.GroupID, wordID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.WordNotInGroup, "Word not found or does not belong to the group")
+		return nil, nil, false
+	} else if err != nil {
+		internalError(c, err, "Failed to fetch word")
+		return nil, nil, false
+	}
+
+	return session, word, true
+}
+
+// sessionOrder describes how study session lists are ordered: most recent
+// first, with the session ID breaking ties
+const sessionOrder = "created_at:desc"
+
+// sessionKey returns the keyset values of a study session, with the start
+// time formatted the way the store's dialect normalizes timestamps
+func sessionKey(session models.StudySessionWithStats) []interface{} {
+	return []interface{}{session.StartTime.UTC().Format("2006-01-02 15:04:05"), session.ID}
+}
+
+// wordEnglishKey returns the keyset values of a word in a list ordered by English
+func wordEnglishKey(word models.WordWithStats) []interface{} {
+	return []interface{}{word.English, word.ID}
 }
@@ -2,46 +2,53 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
 )
 
 func TestGetStudySessions(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, _ := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/study_sessions", GetStudySessions)
+	r.GET("/api/study_sessions", s.GetStudySessions)
 
 	// Test
 	w := testutil.MakeRequest(r, "GET", "/api/study_sessions", nil)
 	testutil.AssertStatus(t, w, 200)
 
 	var response []map[string]interface{}
-	testutil.ParseResponse(t, w, &response)
+	testutil.ParseItems(t, w, &response)
 
 	if len(response) != 2 {
 		t.Errorf("Expected 2 study sessions, got %d", len(response))
 	}
 
-	// Check first session
+	// Check first session; both sessions share a start time, so the newer ID comes first
 	firstSession := response[0]
-	if firstSession["activity_name"] != "Flashcards" { // Most recent session
-		t.Errorf("Expected activity name 'Flashcards', got %v", firstSession["activity_name"])
+	if firstSession["activity_name"] != "Quiz" { // Most recent session
+		t.Errorf("Expected activity name 'Quiz', got %v", firstSession["activity_name"])
 	}
 }
 
 func TestGetStudySession(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, _ := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/study_sessions/:id", GetStudySession)
+	r.GET("/api/study_sessions/:id", s.GetStudySession)
 
 	// Test existing session
 	w := testutil.MakeRequest(r, "GET", "/api/study_sessions/1", nil)
@@ -60,19 +67,19 @@ func TestGetStudySession(t *testing.T) {
 }
 
 func TestGetStudySessionWords(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, conn := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/study_sessions/:id/words", GetStudySessionWords)
+	r.GET("/api/study_sessions/:id/words", s.GetStudySessionWords)
 
 	// Test successful case
 	w := testutil.MakeRequest(r, "GET", "/api/study_sessions/1/words", nil)
 	testutil.AssertStatus(t, w, 200)
 
 	var response []map[string]interface{}
-	testutil.ParseResponse(t, w, &response)
+	testutil.ParseItems(t, w, &response)
 
 	if len(response) != 2 {
 		t.Errorf("Expected 2 words in session, got %d", len(response))
@@ -133,18 +140,43 @@ func TestGetStudySessionWords(t *testing.T) {
 	testutil.AssertStatus(t, w, 400)
 
 	// Test database error
-	db.GetDB().Close()
+	conn.Close()
 	w = testutil.MakeRequest(r, "GET", "/api/study_sessions/1/words", nil)
 	testutil.AssertStatus(t, w, 500)
 }
 
+func TestCreateStudySession(t *testing.T) {
+	t.Parallel()
+	// Setup
+	s, _ := newTestServer(t)
+
+	r := testutil.SetupTestRouter()
+	r.POST("/api/study_sessions", s.CreateStudySession)
+
+	// Test valid session
+	w := testutil.MakeRequest(r, "POST", "/api/study_sessions", bytes.NewBufferString(`{"study_activity_id": 1, "start_time": "2025-02-01T10:00:00Z"}`))
+	testutil.AssertStatus(t, w, 201)
+
+	// Test missing fields
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions", bytes.NewBufferString(`{}`))
+	testutil.AssertStatus(t, w, 422)
+	p := testutil.AssertProblem(t, w, problem.ValidationFailed)
+	assertFields(t, p, "study_activity_id", "start_time")
+
+	// Test unknown activity
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions", bytes.NewBufferString(`{"study_activity_id": 999, "start_time": "2025-02-01T10:00:00Z"}`))
+	testutil.AssertStatus(t, w, 422)
+	p = testutil.AssertProblem(t, w, problem.ValidationFailed)
+	assertFields(t, p, "study_activity_id")
+}
+
 func TestCreateWordReview(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, conn := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.POST("/api/study_sessions/:id/words/:word_id/review", CreateWordReview)
+	r.POST("/api/study_sessions/:id/words/:word_id/review", s.CreateWordReview)
 
 	// Test successful case - correct answer
 	review := struct {
@@ -210,15 +242,21 @@ func TestCreateWordReview(t *testing.T) {
 
 	// Test invalid session ID
 	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/999/words/1/review", bytes.NewBuffer(body))
-	testutil.AssertStatus(t, w, 404) // Session not found
+	testutil.AssertStatus(t, w, 404)
+	testutil.AssertProblem(t, w, problem.StudySessionNotFound)
 
 	// Test invalid word ID
 	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/words/999/review", bytes.NewBuffer(body))
-	testutil.AssertStatus(t, w, 404) // Word not found
+	testutil.AssertStatus(t, w, 404)
+	testutil.AssertProblem(t, w, problem.WordNotInGroup)
 
 	// Test invalid session ID format
 	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/invalid/words/1/review", bytes.NewBuffer(body))
 	testutil.AssertStatus(t, w, 400)
+	p := testutil.AssertProblem(t, w, problem.InvalidParameter)
+	if len(p.Errors) != 1 || p.Errors[0].Field != "id" {
+		t.Errorf("Expected the id parameter to be reported, got %+v", p.Errors)
+	}
 
 	// Test invalid word ID format
 	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/words/invalid/review", bytes.NewBuffer(body))
@@ -236,10 +274,78 @@ func TestCreateWordReview(t *testing.T) {
 	}
 	body, _ = json.Marshal(invalidReview)
 	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/words/1/review", bytes.NewBuffer(body))
-	testutil.AssertStatus(t, w, 400)
+	testutil.AssertStatus(t, w, 422)
+	p = testutil.AssertProblem(t, w, problem.ValidationFailed)
+	assertFields(t, p, "response_time")
+
+	// Test correct is required, even though false is its zero value
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/words/1/review", bytes.NewBufferString(`{"response_time": 1.5}`))
+	testutil.AssertStatus(t, w, 422)
+	p = testutil.AssertProblem(t, w, problem.ValidationFailed)
+	assertFields(t, p, "correct")
+
+	// Test wrongly typed fields
+	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/words/1/review", bytes.NewBufferString(`{"correct": "yes", "response_time": 1.5}`))
+	testutil.AssertStatus(t, w, 422)
+	p = testutil.AssertProblem(t, w, problem.ValidationFailed)
+	assertFields(t, p, "correct")
 
 	// Test database error
-	db.GetDB().Close()
+	conn.Close()
 	w = testutil.MakeRequest(r, "POST", "/api/study_sessions/1/words/1/review", bytes.NewBuffer(body))
 	testutil.AssertStatus(t, w, 500)
 }
+
+func TestCreateWordReviewConcurrent(t *testing.T) {
+	t.Parallel()
+	// Setup a file database connected like the server's
+	conn := testutil.NewTestConn(t, db.Options{
+		JournalMode:  "WAL",
+		Synchronous:  "NORMAL",
+		BusyTimeout:  5 * time.Second,
+		MaxOpenConns: 10,
+		MaxIdleConns: 10,
+	})
+	cfg := testConfig(t)
+	s := NewServer(store.New(conn, media.Dir(cfg.MediaDir)), cfg)
+
+	r := testutil.SetupTestRouter()
+	r.POST("/api/study_sessions/:id/words/:word_id/review", s.CreateWordReview)
+	r.GET("/api/study_sessions/:id/words", s.GetStudySessionWords)
+
+	// Test many clients reviewing and reading at once
+	const clients, reviews = 20, 10
+	var wg sync.WaitGroup
+	failures := make(chan string, clients*reviews*2)
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < reviews; j++ {
+				body := fmt.Sprintf(`{"correct": %t, "response_time": 1.5}`, (i+j)%2 == 0)
+				w := testutil.MakeRequest(r, "POST", "/api/study_sessions/1/words/1/review", bytes.NewBufferString(body))
+				if w.Code != 200 {
+					failures <- fmt.Sprintf("review: %d %s", w.Code, w.Body.String())
+				}
+				w = testutil.MakeRequest(r, "GET", "/api/study_sessions/1/words", nil)
+				if w.Code != 200 {
+					failures <- fmt.Sprintf("read: %d %s", w.Code, w.Body.String())
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(failures)
+	for failure := range failures {
+		t.Errorf("Request failed: %s", failure)
+	}
+
+	// Check every review was stored, after the one in the seed data
+	var count int
+	if err := conn.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM word_review_items WHERE word_id = 1").Scan(&count); err != nil {
+		t.Fatalf("Failed to count reviews: %v", err)
+	}
+	if count != clients*reviews+1 {
+		t.Errorf("Expected %d reviews, got %d", clients*reviews+1, count)
+	}
+}
@@ -1,116 +1,172 @@
 package api
 
 import (
-	"fmt"
+	"errors"
+	"log/slog"
 	"net/http"
-
-	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db/seeds"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
 	"github.com/gin-gonic/gin"
 )
 
 // ResetHistory deletes all study sessions and word review items
-func ResetHistory(c *gin.Context) {
-	db := db.GetDB()
+func (s *Server) ResetHistory(c *gin.Context) {
+	if err := s.store.ResetHistory(c.Request.Context()); err != nil {
+		internalError(c, err, "Failed to reset study history")
+		return
+	}
 
-	// Start a transaction
-	tx, err := db.Begin()
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully reset study history",
+		"details": "Deleted all study sessions and word review items",
+	})
+}
+
+// FullReset resets the entire database to a seed pack, chosen with the seed
+// query parameter and defaulting to the configured one. With seed=none the
+// database is left empty.
+func (s *Server) FullReset(c *gin.Context) {
+	pack := c.DefaultQuery("seed", s.config.SeedPack)
+	seed, err := seeds.Load(pack)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		packs := append(seeds.Names(), seeds.None)
+		problem.Abort(c, problem.InvalidParameter, "Unknown seed pack",
+			problem.Field("seed", "must be one of "+strings.Join(packs, ", ")))
 		return
 	}
 
-	// Delete all word review items
-	_, err = tx.Exec("DELETE FROM word_review_items")
-	if err != nil {
-		if err := tx.Rollback(); err != nil {
-			fmt.Printf("Error rolling back transaction: %v\n", err)
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete word review items"})
+	if err := s.store.FullReset(c.Request.Context(), seed); err != nil {
+		internalError(c, err, "Failed to reset database")
 		return
 	}
 
-	// Delete all study sessions
-	_, err = tx.Exec("DELETE FROM study_sessions")
+	details := "Database has been reset to initial state with the " + pack + " seed pack"
+	if pack == seeds.None {
+		details = "Database has been reset to an empty state"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Successfully reset database",
+		"details":   details,
+		"seed_pack": pack,
+	})
+}
+
+// GetConfig returns the configuration the server is running with, with secrets redacted
+func (s *Server) GetConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, s.config.Redacted())
+}
+
+// GetBackups lists the database backups, newest first
+func (s *Server) GetBackups(c *gin.Context) {
+	backups, err := s.backups.List()
 	if err != nil {
-		if err := tx.Rollback(); err != nil {
-			fmt.Printf("Error rolling back transaction: %v\n", err)
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete study sessions"})
+		internalError(c, err, "Failed to list backups")
 		return
 	}
 
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		if err := tx.Rollback(); err != nil {
-			fmt.Printf("Error rolling back transaction: %v\n", err)
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction"})
+	c.JSON(http.StatusOK, backups)
+}
+
+// CreateBackup copies the running database into a timestamped backup file
+// and prunes backups beyond the retention limit
+func (s *Server) CreateBackup(c *gin.Context) {
+	created, ok := s.backup(c)
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Successfully reset study history",
-		"details": "Deleted all study sessions and word review items",
-	})
+	if err := s.backups.Prune(); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to prune backups", "err", err)
+	}
+
+	c.JSON(http.StatusCreated, created)
 }
 
-// FullReset resets the entire database to its initial state
-func FullReset(c *gin.Context) {
-	db := db.GetDB()
+// DownloadBackup sends a backup file
+func (s *Server) DownloadBackup(c *gin.Context) {
+	path, ok := s.backupPath(c)
+	if !ok {
+		return
+	}
 
-	// Start a transaction
-	tx, err := db.Begin()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+	c.FileAttachment(path, c.Param("name"))
+}
+
+// RestoreBackup replaces the database contents with a backup. The current
+// contents are backed up first, so the restore can itself be undone.
+func (s *Server) RestoreBackup(c *gin.Context) {
+	path, ok := s.backupPath(c)
+	if !ok {
 		return
 	}
 
-	// Delete all data from tables in the correct order
-	tables := []string{
-		"word_review_items",
-		"study_sessions",
-		"word_groups",
-		"words",
-		"groups",
-		"study_activities",
-	}
-
-	for _, table := range tables {
-		_, err = tx.Exec("DELETE FROM " + table)
-		if err != nil {
-			if err := tx.Rollback(); err != nil {
-				fmt.Printf("Error rolling back transaction: %v\n", err)
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete data from " + table})
-			return
-		}
-	}
-
-	// Reset auto-increment counters
-	for _, table := range tables {
-		_, err = tx.Exec("DELETE FROM sqlite_sequence WHERE name = ?", table)
-		if err != nil {
-			if err := tx.Rollback(); err != nil {
-				fmt.Printf("Error rolling back transaction: %v\n", err)
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset auto-increment for " + table})
-			return
-		}
-	}
-
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		if err := tx.Rollback(); err != nil {
-			fmt.Printf("Error rolling back transaction: %v\n", err)
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction"})
+	previous, ok := s.backup(c)
+	if !ok {
 		return
 	}
 
-	// Note: Database will need to be manually reseeded using the setup script
+	err := s.store.Restore(c.Request.Context(), path)
+	if errors.Is(err, store.ErrIncompatible) {
+		problem.Abort(c, problem.BackupIncompatible, "Backup schema version does not match the database")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to restore backup")
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Successfully reset database",
-		"details": "Database has been reset to initial state with seed data",
+		"message":         "Successfully restored backup",
+		"details":         "Database contents were replaced with " + c.Param("name"),
+		"previous_backup": previous,
 	})
 }
+
+// backup takes a new backup, writing an error response if it fails
+func (s *Server) backup(c *gin.Context) (*models.Backup, bool) {
+	path, err := s.backups.NewPath(time.Now())
+	if err != nil {
+		internalError(c, err, "Failed to create backup")
+		return nil, false
+	}
+
+	err = s.store.Backup(c.Request.Context(), path)
+	if err != nil {
+		os.Remove(path)
+	}
+	if errors.Is(err, store.ErrUnsupported) {
+		problem.Abort(c, problem.BackupsUnsupported, "Backups are only supported for SQLite databases")
+		return nil, false
+	} else if err != nil {
+		internalError(c, err, "Failed to create backup")
+		return nil, false
+	}
+
+	created, err := s.backups.Get(filepath.Base(path))
+	if err != nil {
+		internalError(c, err, "Failed to create backup")
+		return nil, false
+	}
+	return created, true
+}
+
+// backupPath resolves the backup named in the URL, writing an error
+// response if it is invalid or missing
+func (s *Server) backupPath(c *gin.Context) (string, bool) {
+	path, err := s.backups.Path(c.Param("name"))
+	if err != nil {
+		problem.Abort(c, problem.InvalidParameter, "Invalid backup name", problem.Field("name", err.Error()))
+		return "", false
+	}
+	if _, err := os.Stat(path); err != nil {
+		problem.Abort(c, problem.BackupNotFound, "Backup not found")
+		return "", false
+	}
+	return path, true
+}
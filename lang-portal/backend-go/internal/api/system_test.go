@@ -1,23 +1,28 @@
 package api
 
 import (
+	"database/sql"
+	"path/filepath"
+	"strings"
 	"testing"
 
-	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/config"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
 )
 
 func TestResetHistory(t *testing.T) {
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, conn := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.POST("/api/reset_history", ResetHistory)
+	r.POST("/api/reset_history", s.ResetHistory)
 
 	// Verify initial state
 	var initialSessionCount int
-	err := db.GetDB().QueryRow("SELECT COUNT(*) FROM study_sessions").Scan(&initialSessionCount)
+	err := conn.QueryRow("SELECT COUNT(*) FROM study_sessions").Scan(&initialSessionCount)
 	if err != nil {
 		t.Fatalf("Failed to count initial sessions: %v", err)
 	}
@@ -26,7 +31,7 @@ func TestResetHistory(t *testing.T) {
 	}
 
 	var initialReviewCount int
-	err = db.GetDB().QueryRow("SELECT COUNT(*) FROM word_review_items").Scan(&initialReviewCount)
+	err = conn.QueryRow("SELECT COUNT(*) FROM word_review_items").Scan(&initialReviewCount)
 	if err != nil {
 		t.Fatalf("Failed to count initial reviews: %v", err)
 	}
@@ -40,7 +45,7 @@ func TestResetHistory(t *testing.T) {
 
 	// Verify reset state
 	var finalSessionCount int
-	err = db.GetDB().QueryRow("SELECT COUNT(*) FROM study_sessions").Scan(&finalSessionCount)
+	err = conn.QueryRow("SELECT COUNT(*) FROM study_sessions").Scan(&finalSessionCount)
 	if err != nil {
 		t.Fatalf("Failed to count final sessions: %v", err)
 	}
@@ -49,7 +54,7 @@ func TestResetHistory(t *testing.T) {
 	}
 
 	var finalReviewCount int
-	err = db.GetDB().QueryRow("SELECT COUNT(*) FROM word_review_items").Scan(&finalReviewCount)
+	err = conn.QueryRow("SELECT COUNT(*) FROM word_review_items").Scan(&finalReviewCount)
 	if err != nil {
 		t.Fatalf("Failed to count final reviews: %v", err)
 	}
@@ -61,7 +66,7 @@ func TestResetHistory(t *testing.T) {
 	tables := []string{"words", "groups", "study_activities"}
 	for _, table := range tables {
 		var count int
-		err = db.GetDB().QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
+		err = conn.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
 		if err != nil {
 			t.Fatalf("Failed to count %s: %v", table, err)
 		}
@@ -75,8 +80,7 @@ func TestResetHistory(t *testing.T) {
 	testutil.AssertStatus(t, w, 200)
 
 	// Test transaction rollback on word_review_items deletion error
-	db := db.GetDB()
-	_, err = db.Exec("DROP TABLE word_review_items")
+	_, err = conn.Exec("DROP TABLE word_review_items")
 	if err != nil {
 		t.Fatalf("Failed to drop word_review_items table: %v", err)
 	}
@@ -84,7 +88,7 @@ func TestResetHistory(t *testing.T) {
 	testutil.AssertStatus(t, w, 500)
 
 	// Recreate word_review_items table
-	_, err = db.Exec(`CREATE TABLE word_review_items (
+	_, err = conn.Exec(`CREATE TABLE word_review_items (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		word_id INTEGER NOT NULL,
 		study_activity_id INTEGER NOT NULL,
@@ -99,7 +103,7 @@ func TestResetHistory(t *testing.T) {
 	}
 
 	// Test transaction rollback on study_sessions deletion error
-	_, err = db.Exec("DROP TABLE study_sessions")
+	_, err = conn.Exec("DROP TABLE study_sessions")
 	if err != nil {
 		t.Fatalf("Failed to drop study_sessions table: %v", err)
 	}
@@ -107,18 +111,17 @@ func TestResetHistory(t *testing.T) {
 	testutil.AssertStatus(t, w, 500)
 
 	// Test database error by closing the connection
-	db.Close()
+	conn.Close()
 	w = testutil.MakeRequest(r, "POST", "/api/reset_history", nil)
 	testutil.AssertStatus(t, w, 500)
 }
 
 func TestFullReset(t *testing.T) {
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, conn := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.POST("/api/full_reset", FullReset)
+	r.POST("/api/full_reset", s.FullReset)
 
 	// Verify initial state
 	tables := []string{
@@ -133,7 +136,7 @@ func TestFullReset(t *testing.T) {
 	initialCounts := make(map[string]int)
 	for _, table := range tables {
 		var count int
-		err := db.GetDB().QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
+		err := conn.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
 		if err != nil {
 			t.Fatalf("Failed to count initial %s: %v", table, err)
 		}
@@ -143,14 +146,46 @@ func TestFullReset(t *testing.T) {
 		}
 	}
 
-	// Test reset
+	// Test reset to the default seed pack
 	w := testutil.MakeRequest(r, "POST", "/api/full_reset", nil)
 	testutil.AssertStatus(t, w, 200)
 
+	var response map[string]interface{}
+	testutil.ParseResponse(t, w, &response)
+	if response["seed_pack"] != "initial_data" {
+		t.Errorf("Expected initial_data seed pack, got %v", response["seed_pack"])
+	}
+	var words int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM words").Scan(&words); err != nil {
+		t.Fatalf("Failed to count words: %v", err)
+	}
+	if words != 15 {
+		t.Errorf("Expected 15 seeded words, got %d", words)
+	}
+
+	// Test reset to another seed pack
+	w = testutil.MakeRequest(r, "POST", "/api/full_reset?seed=travel", nil)
+	testutil.AssertStatus(t, w, 200)
+	var english string
+	if err := conn.QueryRow("SELECT english FROM words WHERE id = 1").Scan(&english); err != nil {
+		t.Fatalf("Failed to read first word: %v", err)
+	}
+	if english != "airport" {
+		t.Errorf("Expected travel words, got %q first", english)
+	}
+
+	// Test unknown seed packs
+	w = testutil.MakeRequest(r, "POST", "/api/full_reset?seed=../words", nil)
+	testutil.AssertStatus(t, w, 400)
+
+	// Test reset to an empty database
+	w = testutil.MakeRequest(r, "POST", "/api/full_reset?seed=none", nil)
+	testutil.AssertStatus(t, w, 200)
+
 	// Verify reset state
 	for _, table := range tables {
 		var count int
-		err := db.GetDB().QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
+		err := conn.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
 		if err != nil {
 			t.Fatalf("Failed to count final %s: %v", table, err)
 		}
@@ -160,7 +195,7 @@ func TestFullReset(t *testing.T) {
 	}
 
 	// Test transaction rollback by breaking the database schema
-	_, err := db.GetDB().Exec("ALTER TABLE words RENAME TO words_temp")
+	_, err := conn.Exec("ALTER TABLE words RENAME TO words_temp")
 	if err != nil {
 		t.Fatalf("Failed to rename words table: %v", err)
 	}
@@ -169,17 +204,139 @@ func TestFullReset(t *testing.T) {
 	testutil.AssertStatus(t, w, 500)
 
 	// Restore the table
-	_, err = db.GetDB().Exec("ALTER TABLE words_temp RENAME TO words")
+	_, err = conn.Exec("ALTER TABLE words_temp RENAME TO words")
 	if err != nil {
 		t.Fatalf("Failed to restore words table: %v", err)
 	}
 
 	// Test resetting an already empty database
-	w = testutil.MakeRequest(r, "POST", "/api/full_reset", nil)
+	w = testutil.MakeRequest(r, "POST", "/api/full_reset?seed=none", nil)
 	testutil.AssertStatus(t, w, 200)
 
 	// Test database error by closing the connection
-	db.GetDB().Close()
+	conn.Close()
 	w = testutil.MakeRequest(r, "POST", "/api/full_reset", nil)
 	testutil.AssertStatus(t, w, 500)
 }
+
+func TestGetConfig(t *testing.T) {
+	// Setup
+	cfg := config.Default()
+	cfg.DBPath = "file:words.db?_auth&_auth_user=admin&_auth_pass=hunter2"
+	cfg.CORSOrigins = []string{"https://app.example.com"}
+
+	r := testutil.SetupTestRouter()
+	r.GET("/api/system/config", NewServer(nil, cfg).GetConfig)
+
+	// Test
+	w := testutil.MakeRequest(r, "GET", "/api/system/config", nil)
+	testutil.AssertStatus(t, w, 200)
+
+	var response map[string]interface{}
+	testutil.ParseResponse(t, w, &response)
+
+	if response["listen_addr"] != ":8080" {
+		t.Errorf("Expected listen_addr ':8080', got %v", response["listen_addr"])
+	}
+	if origins := response["cors_origins"].([]interface{}); len(origins) != 1 || origins[0] != "https://app.example.com" {
+		t.Errorf("Expected configured CORS origins, got %v", origins)
+	}
+	if dbPath := response["db_path"].(string); strings.Contains(dbPath, "hunter2") {
+		t.Errorf("Expected database password to be redacted, got %q", dbPath)
+	}
+}
+
+func TestBackups(t *testing.T) {
+	// Setup
+	cfg := testConfig(t)
+	cfg.BackupRetention = 3
+	s, conn := newTestServerWith(t, cfg)
+	dir := cfg.BackupDir
+
+	r := testutil.SetupTestRouter()
+	r.GET("/api/system/backups", s.GetBackups)
+	r.POST("/api/system/backups", s.CreateBackup)
+	r.GET("/api/system/backups/:name", s.DownloadBackup)
+	r.POST("/api/system/backups/:name/restore", s.RestoreBackup)
+
+	// Test listing without backups
+	w := testutil.MakeRequest(r, "GET", "/api/system/backups", nil)
+	testutil.AssertStatus(t, w, 200)
+	var backups []models.Backup
+	testutil.ParseResponse(t, w, &backups)
+	if len(backups) != 0 {
+		t.Errorf("Expected no backups, got %+v", backups)
+	}
+
+	// Test creating a backup
+	w = testutil.MakeRequest(r, "POST", "/api/system/backups", nil)
+	testutil.AssertStatus(t, w, 201)
+	var created models.Backup
+	testutil.ParseResponse(t, w, &created)
+	if created.Size == 0 || created.URL != "/api/system/backups/"+created.Name {
+		t.Errorf("Unexpected backup: %+v", created)
+	}
+
+	// Test downloading it
+	w = testutil.MakeRequest(r, "GET", created.URL, nil)
+	testutil.AssertStatus(t, w, 200)
+	if !strings.HasPrefix(w.Body.String(), "SQLite format 3") {
+		t.Error("Expected a SQLite database file")
+	}
+
+	// Test restoring it undoes later changes
+	if _, err := conn.Exec("DELETE FROM words WHERE id = 3"); err != nil {
+		t.Fatalf("Failed to delete word: %v", err)
+	}
+	w = testutil.MakeRequest(r, "POST", created.URL+"/restore", nil)
+	testutil.AssertStatus(t, w, 200)
+	var words int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM words").Scan(&words); err != nil {
+		t.Fatalf("Failed to count words: %v", err)
+	}
+	if words != 3 {
+		t.Errorf("Expected 3 words after restore, got %d", words)
+	}
+
+	// Test the restore backed up the replaced contents, and retention
+	for i := 0; i < 3; i++ {
+		w = testutil.MakeRequest(r, "POST", "/api/system/backups", nil)
+		testutil.AssertStatus(t, w, 201)
+	}
+	w = testutil.MakeRequest(r, "GET", "/api/system/backups", nil)
+	testutil.ParseResponse(t, w, &backups)
+	if len(backups) != 3 || backups[0].Name < backups[2].Name {
+		t.Errorf("Expected the 3 newest backups, newest first, got %+v", backups)
+	}
+
+	// Test restoring a backup of another schema version
+	outdated, err := sql.Open("sqlite3", filepath.Join(dir, backups[0].Name))
+	if err != nil {
+		t.Fatalf("Failed to open backup: %v", err)
+	}
+	if _, err := outdated.Exec("DELETE FROM schema_migrations WHERE version = (SELECT MAX(version) FROM schema_migrations)"); err != nil {
+		t.Fatalf("Failed to change backup schema version: %v", err)
+	}
+	outdated.Close()
+	w = testutil.MakeRequest(r, "POST", backups[0].URL+"/restore", nil)
+	testutil.AssertStatus(t, w, 409)
+
+	// Test invalid and missing backups
+	w = testutil.MakeRequest(r, "GET", "/api/system/backups/words.db", nil)
+	testutil.AssertStatus(t, w, 400)
+	w = testutil.MakeRequest(r, "POST", "/api/system/backups/words-20000101-000000.000.db/restore", nil)
+	testutil.AssertStatus(t, w, 404)
+}
+
+func TestBackupsUnsupported(t *testing.T) {
+	// Setup a store that is not SQLite
+	cfg := testConfig(t)
+	s := NewServer(store.NewPostgres(testutil.NewTestDB(t), media.Dir(cfg.MediaDir)), cfg)
+
+	r := testutil.SetupTestRouter()
+	r.POST("/api/system/backups", s.CreateBackup)
+
+	// Test
+	w := testutil.MakeRequest(r, "POST", "/api/system/backups", nil)
+	testutil.AssertStatus(t, w, 501)
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/tags"
+	"github.com/gin-gonic/gin"
+)
+
+// GetTags returns a paginated list of tags with the number of words carrying each
+func (s *Server) GetTags(c *gin.Context) {
+	// Parse pagination parameters
+	page, err := pagination.Parse(c, "name:asc", 2)
+	if err != nil {
+		invalidPagination(c, err)
+		return
+	}
+
+	result, err := s.store.ListTags(c.Request.Context(), page)
+	if err != nil {
+		internalError(c, err, "Failed to fetch tags")
+		return
+	}
+
+	pagination.Respond(c, page, result, func(tag models.TagWithStats) []interface{} {
+		return []interface{}{tag.Name, tag.ID}
+	})
+}
+
+// CreateTag creates a new tag
+func (s *Server) CreateTag(c *gin.Context) {
+	// Parse request body
+	var request struct {
+		Name string `json:"name" binding:"required,tag"`
+	}
+	if !bindJSON(c, &request) {
+		return
+	}
+	name, _ := tags.Normalize(request.Name)
+
+	tag, err := s.store.CreateTag(c.Request.Context(), name)
+	if errors.Is(err, store.ErrConflict) {
+		problem.Abort(c, problem.TagExists, "Tag already exists")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to create tag")
+		return
+	}
+
+	c.JSON(http.StatusCreated, tag)
+}
+
+// UpdateTag renames a tag
+func (s *Server) UpdateTag(c *gin.Context) {
+	tagID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid tag ID")
+		return
+	}
+
+	// Parse request body
+	var request struct {
+		Name string `json:"name" binding:"required,tag"`
+	}
+	if !bindJSON(c, &request) {
+		return
+	}
+	name, _ := tags.Normalize(request.Name)
+
+	tag, err := s.store.UpdateTag(c.Request.Context(), tagID, name)
+	if errors.Is(err, store.ErrConflict) {
+		problem.Abort(c, problem.TagExists, "Tag already exists")
+		return
+	} else if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.TagNotFound, "Tag not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to update tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// DeleteTag deletes a tag and removes it from every word
+func (s *Server) DeleteTag(c *gin.Context) {
+	tagID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid tag ID")
+		return
+	}
+
+	err = s.store.DeleteTag(c.Request.Context(), tagID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.TagNotFound, "Tag not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to delete tag")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddWordTags tags a word, creating any tags that do not exist yet
+func (s *Server) AddWordTags(c *gin.Context) {
+	wordID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid word ID")
+		return
+	}
+
+	// Parse request body
+	var request struct {
+		Tags []string `json:"tags" binding:"required,min=1,dive,tag"`
+	}
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	names := make([]string, len(request.Tags))
+	for i, tag := range request.Tags {
+		names[i], _ = tags.Normalize(tag)
+	}
+
+	wordTags, err := s.store.AddWordTags(c.Request.Context(), wordID, names)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.WordNotFound, "Word not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to tag word")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"word_id": wordID,
+		"tags":    wordTags,
+	})
+}
+
+// DeleteWordTag removes a tag from a word
+func (s *Server) DeleteWordTag(c *gin.Context) {
+	wordID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid word ID")
+		return
+	}
+
+	tagID, err := strconv.Atoi(c.Param("tag_id"))
+	if err != nil {
+		invalidID(c, "tag_id", "Invalid tag ID")
+		return
+	}
+
+	err = s.store.DeleteWordTag(c.Request.Context(), wordID, tagID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.TagNotFound, "Tag not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to untag word")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateGroupFromTags creates a group containing every word matching a tag query
+func (s *Server) CreateGroupFromTags(c *gin.Context) {
+	// Parse request body
+	var request struct {
+		Name  string `json:"name"`
+		Query string `json:"query" binding:"required,tag_query"`
+	}
+	if !bindJSON(c, &request) {
+		return
+	}
+	query, _ := tags.Parse(request.Query)
+
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		name = "Tagged: " + query.String()
+	}
+
+	groupID, wordCount, err := s.store.CreateGroupFromTags(c.Request.Context(), name, query)
+	if err != nil {
+		internalError(c, err, "Failed to create group")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"id":         groupID,
+		"name":       name,
+		"query":      query.String(),
+		"word_count": wordCount,
+	})
+}
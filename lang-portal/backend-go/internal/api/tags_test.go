@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
+	"github.com/gin-gonic/gin"
+)
+
+// setupTagRouter registers the tag routes used by the tests
+func setupTagRouter(s *Server) *gin.Engine {
+	r := testutil.SetupTestRouter()
+	r.GET("/api/tags", s.GetTags)
+	r.POST("/api/tags", s.CreateTag)
+	r.PUT("/api/tags/:id", s.UpdateTag)
+	r.DELETE("/api/tags/:id", s.DeleteTag)
+	r.GET("/api/words", s.GetWords)
+	r.GET("/api/words/:id", s.GetWord)
+	r.POST("/api/words/:id/tags", s.AddWordTags)
+	r.DELETE("/api/words/:id/tags/:tag_id", s.DeleteWordTag)
+	r.GET("/api/groups/:id/words", s.GetGroupWords)
+	r.POST("/api/groups/from_tags", s.CreateGroupFromTags)
+	return r
+}
+
+// tagWord tags a word through the API
+func tagWord(t *testing.T, r *gin.Engine, wordID string, names ...string) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"tags": names})
+	w := testutil.MakeRequest(r, "POST", "/api/words/"+wordID+"/tags", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 200)
+}
+
+func TestTagCRUD(t *testing.T) {
+	t.Parallel()
+	// Setup
+	s, _ := newTestServer(t)
+	r := setupTagRouter(s)
+
+	// Test create normalizes the name
+	body, _ := json.Marshal(map[string]string{"name": "False Friend"})
+	w := testutil.MakeRequest(r, "POST", "/api/tags", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 201)
+
+	var tag map[string]interface{}
+	testutil.ParseResponse(t, w, &tag)
+	if tag["name"] != "false-friend" {
+		t.Errorf("Expected tag name 'false-friend', got %v", tag["name"])
+	}
+
+	// Test duplicate name
+	body, _ = json.Marshal(map[string]string{"name": "false-friend"})
+	w = testutil.MakeRequest(r, "POST", "/api/tags", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, http.StatusConflict)
+
+	// Test invalid name
+	body, _ = json.Marshal(map[string]string{"name": "!!"})
+	w = testutil.MakeRequest(r, "POST", "/api/tags", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 422)
+
+	// Test rename
+	body, _ = json.Marshal(map[string]string{"name": "cognate"})
+	w = testutil.MakeRequest(r, "PUT", "/api/tags/1", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 200)
+	testutil.ParseResponse(t, w, &tag)
+	if tag["name"] != "cognate" {
+		t.Errorf("Expected renamed tag 'cognate', got %v", tag["name"])
+	}
+
+	body, _ = json.Marshal(map[string]string{"name": "unused"})
+	w = testutil.MakeRequest(r, "PUT", "/api/tags/999", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 404)
+
+	// Test list with word counts
+	tagWord(t, r, "1", "cognate", "greeting")
+	w = testutil.MakeRequest(r, "GET", "/api/tags", nil)
+	testutil.AssertStatus(t, w, 200)
+
+	var list []map[string]interface{}
+	testutil.ParseItems(t, w, &list)
+	if len(list) != 2 || list[0]["name"] != "cognate" || list[0]["word_count"] != float64(1) {
+		t.Errorf("Unexpected tag list: %v", list)
+	}
+
+	// Test delete removes the tag from words
+	w = testutil.MakeRequest(r, "DELETE", "/api/tags/1", nil)
+	testutil.AssertStatus(t, w, http.StatusNoContent)
+
+	w = testutil.MakeRequest(r, "GET", "/api/words/1", nil)
+	testutil.AssertStatus(t, w, 200)
+	var word map[string]interface{}
+	testutil.ParseResponse(t, w, &word)
+	wordTags := word["tags"].([]interface{})
+	if len(wordTags) != 1 || wordTags[0] != "greeting" {
+		t.Errorf("Expected only 'greeting' tag, got %v", wordTags)
+	}
+
+	w = testutil.MakeRequest(r, "DELETE", "/api/tags/1", nil)
+	testutil.AssertStatus(t, w, 404)
+}
+
+func TestWordTags(t *testing.T) {
+	t.Parallel()
+	// Setup
+	s, _ := newTestServer(t)
+	r := setupTagRouter(s)
+
+	// Test tagging creates tags and ignores duplicates
+	body, _ := json.Marshal(map[string]interface{}{"tags": []string{"Verb", "irregular", "verb"}})
+	w := testutil.MakeRequest(r, "POST", "/api/words/1/tags", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 200)
+
+	var response struct {
+		Tags []string `json:"tags"`
+	}
+	testutil.ParseResponse(t, w, &response)
+	if len(response.Tags) != 2 || response.Tags[0] != "irregular" || response.Tags[1] != "verb" {
+		t.Errorf("Expected tags [irregular verb], got %v", response.Tags)
+	}
+
+	// Test non-existent word
+	w = testutil.MakeRequest(r, "POST", "/api/words/999/tags", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 404)
+
+	// Test invalid tag
+	body, _ = json.Marshal(map[string]interface{}{"tags": []string{"verb", "a,b"}})
+	w = testutil.MakeRequest(r, "POST", "/api/words/1/tags", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 422)
+	p := testutil.AssertProblem(t, w, problem.ValidationFailed)
+	assertFields(t, p, "tags[1]")
+
+	// Test untagging
+	w = testutil.MakeRequest(r, "DELETE", "/api/words/1/tags/2", nil)
+	testutil.AssertStatus(t, w, http.StatusNoContent)
+	w = testutil.MakeRequest(r, "DELETE", "/api/words/1/tags/2", nil)
+	testutil.AssertStatus(t, w, 404)
+
+	// Test tags appear on group word lists
+	w = testutil.MakeRequest(r, "GET", "/api/groups/1/words", nil)
+	testutil.AssertStatus(t, w, 200)
+	var words []struct {
+		ID   int      `json:"id"`
+		Tags []string `json:"tags"`
+	}
+	testutil.ParseItems(t, w, &words)
+	for _, word := range words {
+		if word.ID == 1 && (len(word.Tags) != 1 || word.Tags[0] != "verb") {
+			t.Errorf("Expected word 1 to be tagged 'verb', got %v", word.Tags)
+		}
+	}
+}
+
+func TestGetWordsTagFilter(t *testing.T) {
+	t.Parallel()
+	// Setup
+	s, _ := newTestServer(t)
+	r := setupTagRouter(s)
+
+	tagWord(t, r, "1", "verb", "irregular")
+	tagWord(t, r, "2", "verb")
+	tagWord(t, r, "3", "noun")
+
+	tests := []struct {
+		query    string
+		expected []int
+	}{
+		{"verb", []int{1, 2}},
+		{"verb,-irregular", []int{2}},
+		{"-verb", []int{3}},
+		{"noun|irregular", []int{1, 3}},
+		{"exam-2025", []int{}},
+	}
+
+	for _, tt := range tests {
+		w := testutil.MakeRequest(r, "GET", "/api/words?tags="+tt.query, nil)
+		testutil.AssertStatus(t, w, 200)
+
+		var response struct {
+			Words []struct {
+				ID int `json:"id"`
+			} `json:"items"`
+		}
+		testutil.ParseResponse(t, w, &response)
+
+		if len(response.Words) != len(tt.expected) {
+			t.Errorf("tags=%s: expected words %v, got %v", tt.query, tt.expected, response.Words)
+			continue
+		}
+		for i, id := range tt.expected {
+			if response.Words[i].ID != id {
+				t.Errorf("tags=%s: expected words %v, got %v", tt.query, tt.expected, response.Words)
+			}
+		}
+	}
+
+	// Test invalid query
+	w := testutil.MakeRequest(r, "GET", "/api/words?tags=verb,,noun", nil)
+	testutil.AssertStatus(t, w, 400)
+}
+
+func TestCreateGroupFromTags(t *testing.T) {
+	t.Parallel()
+	// Setup
+	s, _ := newTestServer(t)
+	r := setupTagRouter(s)
+
+	tagWord(t, r, "1", "verb", "irregular")
+	tagWord(t, r, "2", "verb")
+	tagWord(t, r, "3", "verb")
+
+	// Test
+	body, _ := json.Marshal(map[string]string{"query": "verb,-irregular"})
+	w := testutil.MakeRequest(r, "POST", "/api/groups/from_tags", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 201)
+
+	var group map[string]interface{}
+	testutil.ParseResponse(t, w, &group)
+	if group["name"] != "Tagged: verb,-irregular" {
+		t.Errorf("Expected default group name, got %v", group["name"])
+	}
+	if group["word_count"] != float64(2) {
+		t.Errorf("Expected 2 words in group, got %v", group["word_count"])
+	}
+
+	w = testutil.MakeRequest(r, "GET", "/api/groups/3/words", nil)
+	testutil.AssertStatus(t, w, 200)
+	var words []map[string]interface{}
+	testutil.ParseItems(t, w, &words)
+	if len(words) != 2 {
+		t.Errorf("Expected 2 group words, got %d", len(words))
+	}
+
+	// Test invalid query
+	body, _ = json.Marshal(map[string]string{"name": "Broken", "query": ""})
+	w = testutil.MakeRequest(r, "POST", "/api/groups/from_tags", bytes.NewBuffer(body))
+	testutil.AssertStatus(t, w, 422)
+}
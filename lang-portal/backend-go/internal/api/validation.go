@@ -0,0 +1,152 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/conjugation"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/quiz"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/tags"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// rule is a validation usable in binding tags, along with the message
+// reported for fields that break it
+type rule struct {
+	valid   func(value string) bool
+	message string
+}
+
+// rules lists the validations this package adds to the built-in ones
+var rules = map[string]rule{
+	"notblank": {
+		valid:   func(value string) bool { return strings.TrimSpace(value) != "" },
+		message: "must not be blank",
+	},
+	"tense": {
+		valid:   func(value string) bool { _, ok := conjugation.ParseTense(value); return ok },
+		message: "must be one of " + joinValues(conjugation.Tenses),
+	},
+	"person": {
+		valid:   func(value string) bool { _, ok := conjugation.ParsePerson(value); return ok },
+		message: "must be one of " + joinValues(conjugation.Persons),
+	},
+	"question_type": {
+		valid:   func(value string) bool { _, ok := quiz.ParseType(value); return ok },
+		message: "must be one of " + joinValues(quiz.Types),
+	},
+	"tag": {
+		valid:   func(value string) bool { _, err := tags.Normalize(value); return err == nil },
+		message: "must be a valid tag name",
+	},
+	"tag_query": {
+		valid:   func(value string) bool { _, err := tags.Parse(value); return err == nil },
+		message: "must be a valid tag query",
+	},
+}
+
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	// Report fields by their JSON names
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	for tag, r := range rules {
+		valid := r.valid
+		v.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+			return valid(fl.Field().String())
+		})
+	}
+}
+
+// bindJSON decodes the request body into v and validates it against its
+// binding tags. If the body is malformed or any field is invalid it writes a
+// problem and returns false.
+func bindJSON(c *gin.Context, v interface{}) bool {
+	err := c.ShouldBindJSON(v)
+	var invalid validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case err == nil:
+		return true
+	case errors.As(err, &maxBytesErr):
+		problem.Abort(c, problem.BodyTooLarge, fmt.Sprintf("Request body must not exceed %d bytes", maxBytesErr.Limit))
+	case errors.As(err, &invalid):
+		fields := make([]problem.FieldError, len(invalid))
+		for i, e := range invalid {
+			fields[i] = problem.Field(e.Field(), fieldMessage(e))
+		}
+		problem.Abort(c, problem.ValidationFailed, "Request validation failed", fields...)
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		problem.Abort(c, problem.ValidationFailed, "Request validation failed",
+			problem.Field(typeErr.Field, "must be of type "+typeErr.Type.String()))
+	default:
+		problem.Abort(c, problem.InvalidBody, err.Error())
+	}
+	return false
+}
+
+// fieldMessage describes a failed validation
+func fieldMessage(e validator.FieldError) string {
+	if r, ok := rules[e.Tag()]; ok {
+		return r.message
+	}
+
+	unit := ""
+	switch e.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice:
+		unit = " items"
+	}
+	switch e.Tag() {
+	case "required":
+		return "is required"
+	case "gt":
+		return "must be greater than " + e.Param()
+	case "min":
+		if unit == "" {
+			return "must be at least " + e.Param()
+		}
+		return "must have at least " + e.Param() + unit
+	case "max":
+		if unit == "" {
+			return "must be at most " + e.Param()
+		}
+		return "must have at most " + e.Param() + unit
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(e.Param(), " ", ", ")
+	}
+	return fmt.Sprintf("failed the %s check", e.Tag())
+}
+
+// referenceError reports a field referring to a record that does not exist
+func referenceError(c *gin.Context, fields ...problem.FieldError) {
+	problem.Abort(c, problem.ValidationFailed, "Request refers to records that do not exist", fields...)
+}
+
+// joinValues lists allowed values for a message
+func joinValues[T ~string](values []T) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+	return strings.Join(s, ", ")
+}
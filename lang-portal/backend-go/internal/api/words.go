@@ -1,144 +1,186 @@
 package api
 
 import (
-	"database/sql"
+	"errors"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
-	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/tags"
 	"github.com/gin-gonic/gin"
 )
 
 // GetWords returns a paginated list of words with their statistics
-func GetWords(c *gin.Context) {
-	db := db.GetDB()
+func (s *Server) GetWords(c *gin.Context) {
+	// Parse filters and sort order
+	filter, invalid := parseWordFilter(c)
+	if len(invalid) > 0 {
+		problem.Abort(c, problem.InvalidParameter, "Invalid filter", invalid...)
+		return
+	}
 
 	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "100"))
-	if page < 1 {
-		page = 1
+	direction := "ASC"
+	if filter.Desc {
+		direction = "DESC"
 	}
-	if perPage < 1 || perPage > 100 {
-		perPage = 100
-	}
-	offset := (page - 1) * perPage
-
-	// Get total count
-	var totalItems int
-	err := db.QueryRow("SELECT COUNT(*) FROM words").Scan(&totalItems)
+	page, err := pagination.Parse(c, filter.Sort+":"+direction, 2)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count words"})
+		invalidPagination(c, err)
 		return
 	}
 
-	// Get words with stats
-	rows, err := db.Query(`
-		SELECT 
-			w.id,
-			w.english,
-			w.spanish,
-			w.level,
-			w.created_at,
-			w.updated_at,
-			COUNT(CASE WHEN wri.correct = 1 THEN 1 END) as correct_count,
-			COUNT(CASE WHEN wri.correct = 0 THEN 1 END) as incorrect_count,
-			COALESCE(AVG(CASE WHEN wri.correct THEN 1.0 ELSE 0.0 END), 0) as mastery_level
-		FROM words w
-		LEFT JOIN word_review_items wri ON w.id = wri.word_id
-		GROUP BY w.id
-		ORDER BY w.id
-		LIMIT ? OFFSET ?
-	`, perPage, offset)
+	// Get words with stats, images and tags
+	words, err := s.store.ListWords(c.Request.Context(), *filter, page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch words"})
+		internalError(c, err, "Failed to fetch words")
 		return
 	}
-	defer rows.Close()
-
-	var words []models.WordWithStats
-	for rows.Next() {
-		var word models.WordWithStats
-		err := rows.Scan(
-			&word.ID,
-			&word.English,
-			&word.Spanish,
-			&word.Level,
-			&word.CreatedAt,
-			&word.UpdatedAt,
-			&word.CorrectCount,
-			&word.IncorrectCount,
-			&word.MasteryLevel,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan word"})
-			return
-		}
-		words = append(words, word)
-	}
 
-	// Calculate pagination metadata
-	totalPages := (totalItems + perPage - 1) / perPage
-
-	c.JSON(http.StatusOK, gin.H{
-		"words": words,
-		"pagination": gin.H{
-			"current_page": page,
-			"total_pages":  totalPages,
-			"total_items":  totalItems,
-			"per_page":     perPage,
-		},
+	pagination.Respond(c, page, words, func(word models.WordWithStats) []interface{} {
+		return []interface{}{word.SortKey, word.ID}
 	})
 }
 
 // GetWord returns details for a specific word
-func GetWord(c *gin.Context) {
-	db := db.GetDB()
-
+func (s *Server) GetWord(c *gin.Context) {
 	// Parse word ID
 	wordID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid word ID"})
+		invalidID(c, "id", "Invalid word ID")
 		return
 	}
 
-	// Get word with stats
-	var word models.WordWithStats
-	err = db.QueryRow(`
-		SELECT 
-			w.id,
-			w.english,
-			w.spanish,
-			w.level,
-			w.created_at,
-			w.updated_at,
-			COUNT(CASE WHEN wri.correct = 1 THEN 1 END) as correct_count,
-			COUNT(CASE WHEN wri.correct = 0 THEN 1 END) as incorrect_count,
-			COALESCE(AVG(CASE WHEN wri.correct THEN 1.0 ELSE 0.0 END), 0) as mastery_level
-		FROM words w
-		LEFT JOIN word_review_items wri ON w.id = wri.word_id
-		WHERE w.id = ?
-		GROUP BY w.id
-	`, wordID).Scan(
-		&word.ID,
-		&word.English,
-		&word.Spanish,
-		&word.Level,
-		&word.CreatedAt,
-		&word.UpdatedAt,
-		&word.CorrectCount,
-		&word.IncorrectCount,
-		&word.MasteryLevel,
-	)
-
-	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Word not found"})
+	// Get word with stats, images, tags and pronunciation clips
+	word, err := s.store.GetWord(c.Request.Context(), wordID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.WordNotFound, "Word not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch word"})
+		internalError(c, err, "Failed to fetch word")
 		return
 	}
 
 	c.JSON(http.StatusOK, word)
 }
+
+// DeleteWord deletes a word along with its reviews, group links, tags, audio clips and images
+func (s *Server) DeleteWord(c *gin.Context) {
+	wordID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidID(c, "id", "Invalid word ID")
+		return
+	}
+
+	err = s.store.DeleteWord(c.Request.Context(), wordID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.WordNotFound, "Word not found")
+		return
+	} else if err != nil {
+		internalError(c, err, "Failed to delete word")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// wordExists checks that a word exists, writing an error response and
+// returning false if it does not
+func (s *Server) wordExists(c *gin.Context, wordID int) bool {
+	_, err := s.store.FindWord(c.Request.Context(), wordID)
+	if errors.Is(err, store.ErrNotFound) {
+		problem.Abort(c, problem.WordNotFound, "Word not found")
+		return false
+	} else if err != nil {
+		internalError(c, err, "Failed to check word existence")
+		return false
+	}
+	return true
+}
+
+// parseWordFilter reads the filter and sort parameters accepted by GetWords,
+// along with every parameter that is invalid
+func parseWordFilter(c *gin.Context) (*store.WordFilter, []problem.FieldError) {
+	filter := &store.WordFilter{Level: c.Query("level")}
+	var invalid []problem.FieldError
+
+	if value := c.Query("group_id"); value != "" {
+		groupID, err := strconv.Atoi(value)
+		if err != nil {
+			invalid = append(invalid, problem.Field("group_id", "must be an integer"))
+		}
+		filter.GroupID = groupID
+	}
+
+	if value := c.Query("created_since"); value != "" {
+		since, err := parseTime(value)
+		if err != nil {
+			invalid = append(invalid, problem.Field("created_since", "must be a date (YYYY-MM-DD) or RFC 3339 timestamp"))
+		}
+		filter.CreatedSince = since
+	}
+
+	if expr := c.Query("tags"); expr != "" {
+		query, err := tags.Parse(expr)
+		if err != nil {
+			invalid = append(invalid, problem.Field("tags", err.Error()))
+		} else {
+			filter.Tags = &query
+		}
+	}
+
+	for _, bound := range []struct {
+		param  string
+		target **float64
+	}{
+		{"mastery_min", &filter.MasteryMin},
+		{"mastery_max", &filter.MasteryMax},
+	} {
+		value := c.Query(bound.param)
+		if value == "" {
+			continue
+		}
+		mastery, err := strconv.ParseFloat(value, 64)
+		if err != nil || mastery < 0 || mastery > 1 {
+			invalid = append(invalid, problem.Field(bound.param, "must be a number between 0 and 1"))
+			continue
+		}
+		*bound.target = &mastery
+	}
+
+	if value := c.Query("reviewed"); value != "" {
+		reviewed, err := strconv.ParseBool(value)
+		if err != nil {
+			invalid = append(invalid, problem.Field("reviewed", "must be true or false"))
+		}
+		filter.Reviewed = &reviewed
+	}
+
+	filter.Sort = c.DefaultQuery("sort", "id")
+	if !slices.Contains(store.WordSorts, filter.Sort) {
+		invalid = append(invalid, problem.Field("sort", "must be one of id, english, spanish, correct_count, incorrect_count, mastery or last_reviewed"))
+	}
+	switch strings.ToLower(c.DefaultQuery("order", "asc")) {
+	case "asc":
+	case "desc":
+		filter.Desc = true
+	default:
+		invalid = append(invalid, problem.Field("order", "must be asc or desc"))
+	}
+
+	return filter, invalid
+}
+
+// parseTime accepts either a date or an RFC 3339 timestamp
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
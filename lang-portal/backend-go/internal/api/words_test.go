@@ -1,18 +1,20 @@
 package api
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
 )
 
 func TestGetWords(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, _ := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/words", GetWords)
+	r.GET("/api/words", s.GetWords)
 
 	// Test
 	w := testutil.MakeRequest(r, "GET", "/api/words", nil)
@@ -21,7 +23,7 @@ func TestGetWords(t *testing.T) {
 	testutil.AssertStatus(t, w, 200)
 
 	var response struct {
-		Words []map[string]interface{} `json:"words"`
+		Words []map[string]interface{} `json:"items"`
 	}
 	testutil.ParseResponse(t, w, &response)
 
@@ -37,12 +39,12 @@ func TestGetWords(t *testing.T) {
 }
 
 func TestGetWord(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, _ := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/words/:id", GetWord)
+	r.GET("/api/words/:id", s.GetWord)
 
 	// Test existing word
 	w := testutil.MakeRequest(r, "GET", "/api/words/1", nil)
@@ -61,12 +63,12 @@ func TestGetWord(t *testing.T) {
 }
 
 func TestGetWordStats(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
+	s, _ := newTestServer(t)
 
 	r := testutil.SetupTestRouter()
-	r.GET("/api/words/:id", GetWord)
+	r.GET("/api/words/:id", s.GetWord)
 
 	// Test word with review history
 	w := testutil.MakeRequest(r, "GET", "/api/words/1", nil)
@@ -86,3 +88,168 @@ func TestGetWordStats(t *testing.T) {
 		t.Errorf("Expected mastery_level to be 1, got %v", response["mastery_level"])
 	}
 }
+
+func TestGetWordsFilters(t *testing.T) {
+	t.Parallel()
+	// Setup
+	s, conn := newTestServer(t)
+
+	_, err := conn.Exec(`
+		INSERT INTO words (id, english, spanish, level, created_at) VALUES
+		(4, 'apple', 'manzana', 'intermediate', '2030-01-01 00:00:00');
+		INSERT INTO word_review_items (word_id, study_activity_id, correct, response_time, created_at) VALUES
+		(1, 1, 0, 1.0, '2030-01-02 00:00:00');
+	`)
+	if err != nil {
+		t.Fatalf("Failed to insert test data: %v", err)
+	}
+
+	r := testutil.SetupTestRouter()
+	r.GET("/api/words", s.GetWords)
+
+	tests := []struct {
+		query    string
+		expected []int
+	}{
+		{"", []int{1, 2, 3, 4}},
+		{"level=intermediate", []int{4}},
+		{"group_id=1", []int{1, 2}},
+		{"reviewed=false", []int{4}},
+		{"reviewed=true&mastery_min=0.5", []int{1, 3}},
+		{"mastery_max=0.5&reviewed=true", []int{1, 2}},
+		{"created_since=2029-12-31", []int{4}},
+		{"sort=english", []int{4, 2, 1, 3}},
+		{"sort=spanish&order=desc", []int{4, 1, 3, 2}},
+		{"sort=mastery&order=desc", []int{3, 1, 4, 2}},
+		{"sort=incorrect_count&order=desc&group_id=1", []int{2, 1}},
+		{"sort=last_reviewed&order=desc&reviewed=true", []int{1, 3, 2}},
+	}
+
+	for _, tt := range tests {
+		w := testutil.MakeRequest(r, "GET", "/api/words?"+tt.query, nil)
+		testutil.AssertStatus(t, w, 200)
+
+		var response struct {
+			Words []struct {
+				ID int `json:"id"`
+			} `json:"items"`
+		}
+		testutil.ParseResponse(t, w, &response)
+
+		var ids []int
+		for _, word := range response.Words {
+			ids = append(ids, word.ID)
+		}
+		if len(ids) != len(tt.expected) {
+			t.Errorf("%q: expected words %v, got %v", tt.query, tt.expected, ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tt.expected[i] {
+				t.Errorf("%q: expected words %v, got %v", tt.query, tt.expected, ids)
+				break
+			}
+		}
+	}
+
+	// Test invalid parameters
+	for _, query := range []string{
+		"sort=w.id%3BDROP%20TABLE%20words",
+		"order=sideways",
+		"mastery_min=2",
+		"mastery_max=abc",
+		"reviewed=maybe",
+		"group_id=one",
+		"created_since=yesterday",
+	} {
+		w := testutil.MakeRequest(r, "GET", "/api/words?"+query, nil)
+		testutil.AssertStatus(t, w, 400)
+		testutil.AssertProblem(t, w, problem.InvalidParameter)
+	}
+
+	// Test every invalid parameter is reported
+	w := testutil.MakeRequest(r, "GET", "/api/words?order=sideways&reviewed=maybe&group_id=one", nil)
+	testutil.AssertStatus(t, w, 400)
+	p := testutil.AssertProblem(t, w, problem.InvalidParameter)
+	var fields []string
+	for _, e := range p.Errors {
+		fields = append(fields, e.Field)
+	}
+	if strings.Join(fields, ",") != "group_id,reviewed,order" {
+		t.Errorf("Expected group_id, reviewed and order to be reported, got %v", fields)
+	}
+}
+
+func TestGetWordsPagination(t *testing.T) {
+	t.Parallel()
+	// Setup
+	s, _ := newTestServer(t)
+
+	r := testutil.SetupTestRouter()
+	r.GET("/api/words", s.GetWords)
+
+	tests := []struct {
+		query    string
+		expected []int
+	}{
+		{"sort=id", []int{1, 2, 3}},
+		{"sort=mastery&order=desc", []int{3, 1, 2}},
+		{"sort=english", []int{2, 1, 3}},
+		{"sort=last_reviewed&order=desc", []int{3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		// Test walking one word at a time
+		var ids []int
+		path := "/api/words?limit=1&" + tt.query
+		for path != "" && len(ids) <= len(tt.expected) {
+			w := testutil.MakeRequest(r, "GET", path, nil)
+			testutil.AssertStatus(t, w, 200)
+
+			var response struct {
+				Items []struct {
+					ID int `json:"id"`
+				} `json:"items"`
+				Pagination struct {
+					NextCursor string `json:"next_cursor"`
+					HasMore    bool   `json:"has_more"`
+				} `json:"pagination"`
+			}
+			testutil.ParseResponse(t, w, &response)
+			for _, word := range response.Items {
+				ids = append(ids, word.ID)
+			}
+
+			path = ""
+			if response.Pagination.HasMore {
+				path = "/api/words?limit=1&" + tt.query + "&cursor=" + response.Pagination.NextCursor
+			}
+		}
+
+		if len(ids) != len(tt.expected) {
+			t.Errorf("%q: expected words %v, got %v", tt.query, tt.expected, ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tt.expected[i] {
+				t.Errorf("%q: expected words %v, got %v", tt.query, tt.expected, ids)
+				break
+			}
+		}
+	}
+
+	// Test a cursor issued for another sort order is rejected
+	w := testutil.MakeRequest(r, "GET", "/api/words?limit=1&sort=english", nil)
+	var response struct {
+		Pagination struct {
+			NextCursor string `json:"next_cursor"`
+		} `json:"pagination"`
+	}
+	testutil.ParseResponse(t, w, &response)
+	w = testutil.MakeRequest(r, "GET", "/api/words?sort=spanish&cursor="+response.Pagination.NextCursor, nil)
+	testutil.AssertStatus(t, w, 400)
+
+	// Test invalid limit
+	w = testutil.MakeRequest(r, "GET", "/api/words?limit=0", nil)
+	testutil.AssertStatus(t, w, 400)
+}
@@ -0,0 +1,141 @@
+// Package backup names, lists and prunes the database backup files kept in
+// the backup directory
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+)
+
+// ErrInvalidName is returned for names that are not backup file names
+var ErrInvalidName = errors.New("invalid backup name")
+
+// Backup file names are the prefix, the UTC time of the backup and the suffix,
+// so they sort in the order the backups were taken
+const (
+	prefix     = "words-"
+	suffix     = ".db"
+	timeFormat = "20060102-150405.000"
+)
+
+// Dir is a directory of backups
+type Dir struct {
+	path      string
+	retention int
+}
+
+// NewDir returns the backup directory at path, of which Prune keeps the
+// newest retention backups; zero keeps every backup
+func NewDir(path string, retention int) *Dir {
+	return &Dir{path: path, retention: retention}
+}
+
+// NewPath creates the backup directory if needed and reserves the path for a
+// backup taken at t by creating an empty file there, which the caller fills
+// or removes. If a backup already has that name, t is moved forward a
+// millisecond at a time until a name can be reserved.
+func (d *Dir) NewPath(t time.Time) (string, error) {
+	if err := os.MkdirAll(d.path, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %v", err)
+	}
+	for {
+		path := filepath.Join(d.path, prefix+t.UTC().Format(timeFormat)+suffix)
+		f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			return path, f.Close()
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return "", fmt.Errorf("failed to reserve backup name: %v", err)
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// List returns the backups, newest first
+func (d *Dir) List() ([]models.Backup, error) {
+	entries, err := os.ReadDir(d.path)
+	if os.IsNotExist(err) {
+		return []models.Backup{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []models.Backup{}
+	for _, entry := range entries {
+		if entry.IsDir() || !validName(entry.Name()) {
+			continue
+		}
+		backup, err := d.Get(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, *backup)
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Name > backups[j].Name
+	})
+	return backups, nil
+}
+
+// Get describes the named backup. It returns ErrInvalidName for names that
+// are not backup file names, and an os.ErrNotExist error for missing backups.
+func (d *Dir) Get(name string) (*models.Backup, error) {
+	path, err := d.Path(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Backup{
+		Name:      name,
+		Size:      info.Size(),
+		CreatedAt: info.ModTime().UTC(),
+		URL:       models.BackupURL(name),
+	}, nil
+}
+
+// Path returns the path of the named backup, or ErrInvalidName for names
+// that are not backup file names
+func (d *Dir) Path(name string) (string, error) {
+	if !validName(name) {
+		return "", ErrInvalidName
+	}
+	return filepath.Join(d.path, name), nil
+}
+
+// Prune deletes the oldest backups beyond the retention limit
+func (d *Dir) Prune() error {
+	if d.retention <= 0 {
+		return nil
+	}
+	backups, err := d.List()
+	if err != nil {
+		return err
+	}
+	for i := d.retention; i < len(backups); i++ {
+		if err := os.Remove(filepath.Join(d.path, backups[i].Name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// validName reports whether name has the form NewPath gives backups
+func validName(name string) bool {
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+		return false
+	}
+	_, err := time.Parse(timeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix))
+	return err == nil
+}
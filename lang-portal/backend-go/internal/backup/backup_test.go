@@ -0,0 +1,126 @@
+package backup
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"words-20250214-214902.123.db", true},
+		{"words.db", false},
+		{"../words-20250214-214902.123.db", false},
+		{"words-20250214-214902.123.db.tmp", false},
+		{"words-tomorrow.db", false},
+	}
+
+	for _, tt := range tests {
+		_, err := NewDir("backups", 10).Path(tt.name)
+		if valid := !errors.Is(err, ErrInvalidName); valid != tt.valid {
+			t.Errorf("Path(%q): expected valid %v, got error %v", tt.name, tt.valid, err)
+		}
+	}
+}
+
+func TestPrune(t *testing.T) {
+	// Setup
+	d := NewDir(t.TempDir(), 2)
+
+	start := time.Date(2025, 2, 14, 21, 49, 2, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		path, err := d.NewPath(start.Add(time.Duration(i) * time.Minute))
+		if err != nil {
+			t.Fatalf("Failed to name backup: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("backup"), 0o644); err != nil {
+			t.Fatalf("Failed to write backup: %v", err)
+		}
+	}
+
+	// Test the newest backups are kept
+	if err := d.Prune(); err != nil {
+		t.Fatalf("Failed to prune: %v", err)
+	}
+	backups, err := d.List()
+	if err != nil {
+		t.Fatalf("Failed to list backups: %v", err)
+	}
+	if len(backups) != 2 || backups[0].Name != "words-20250214-215202.000.db" || backups[1].Name != "words-20250214-215102.000.db" {
+		t.Errorf("Expected the 2 newest backups, got %+v", backups)
+	}
+}
+
+func TestNewPathTaken(t *testing.T) {
+	// Setup
+	d := NewDir(t.TempDir(), 10)
+	now := time.Date(2025, 2, 14, 21, 49, 2, 0, time.UTC)
+	first, err := d.NewPath(now)
+	if err != nil {
+		t.Fatalf("Failed to name backup: %v", err)
+	}
+	if err := os.WriteFile(first, []byte("backup"), 0o644); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+
+	// Test a second backup at the same time gets the next free name
+	second, err := d.NewPath(now)
+	if err != nil {
+		t.Fatalf("Failed to name backup: %v", err)
+	}
+	if filepath.Base(second) != "words-20250214-214902.001.db" {
+		t.Errorf("Expected the next millisecond, got %s", second)
+	}
+}
+
+func TestNewPathConcurrent(t *testing.T) {
+	// Setup
+	d := NewDir(t.TempDir(), 10)
+	now := time.Date(2025, 2, 14, 21, 49, 2, 0, time.UTC)
+
+	// Test backups named at the same time all reserve distinct names
+	paths := make([]string, 8)
+	errs := make([]error, len(paths))
+	var wg sync.WaitGroup
+	for i := range paths {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			paths[i], errs[i] = d.NewPath(now)
+		}(i)
+	}
+	wg.Wait()
+	seen := map[string]bool{}
+	for i, path := range paths {
+		if errs[i] != nil {
+			t.Fatalf("Failed to name backup: %v", errs[i])
+		}
+		if seen[path] {
+			t.Errorf("Expected distinct names, got %s twice", path)
+		}
+		seen[path] = true
+	}
+}
+
+func TestNewPathError(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can write to read-only directories")
+	}
+	// Setup a read-only backup directory
+	dir := t.TempDir()
+	if err := os.Chmod(dir, 0o555); err != nil {
+		t.Fatalf("Failed to make directory read-only: %v", err)
+	}
+	defer os.Chmod(dir, 0o755)
+
+	// Test the error is returned rather than retried
+	if _, err := NewDir(dir, 10).NewPath(time.Now()); err == nil {
+		t.Error("Expected an error for a read-only backup directory")
+	}
+}
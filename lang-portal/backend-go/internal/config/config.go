@@ -0,0 +1,446 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db/seeds"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is prepended to the environment variable for each setting
+const EnvPrefix = "LANGPORTAL_"
+
+// Config holds the server and database settings. Values are resolved in
+// order of increasing precedence: defaults, config file, environment
+// variables, command line flags.
+type Config struct {
+	ListenAddr    string   `json:"listen_addr" yaml:"listen_addr" toml:"listen_addr"`
+	DrainTimeout  Duration `json:"drain_timeout" yaml:"drain_timeout" toml:"drain_timeout"`
+	DBPath        string   `json:"db_path" yaml:"db_path" toml:"db_path"`
+	MigrationsDir string   `json:"migrations_dir" yaml:"migrations_dir" toml:"migrations_dir"`
+	SeedFile      string   `json:"seed_file" yaml:"seed_file" toml:"seed_file"`
+	SeedPack      string   `json:"seed_pack" yaml:"seed_pack" toml:"seed_pack"`
+	CORSOrigins   []string `json:"cors_origins" yaml:"cors_origins" toml:"cors_origins"`
+	LogLevel      string   `json:"log_level" yaml:"log_level" toml:"log_level"`
+	LogFormat     string   `json:"log_format" yaml:"log_format" toml:"log_format"`
+	MediaDir      string   `json:"media_dir" yaml:"media_dir" toml:"media_dir"`
+	GinMode       string   `json:"gin_mode" yaml:"gin_mode" toml:"gin_mode"`
+
+	// SQLite tuning, ignored for PostgreSQL
+	SQLiteJournalMode string   `json:"sqlite_journal_mode" yaml:"sqlite_journal_mode" toml:"sqlite_journal_mode"`
+	SQLiteSynchronous string   `json:"sqlite_synchronous" yaml:"sqlite_synchronous" toml:"sqlite_synchronous"`
+	SQLiteBusyTimeout Duration `json:"sqlite_busy_timeout" yaml:"sqlite_busy_timeout" toml:"sqlite_busy_timeout"`
+
+	// Connection pool limits
+	DBMaxOpenConns int `json:"db_max_open_conns" yaml:"db_max_open_conns" toml:"db_max_open_conns"`
+	DBMaxIdleConns int `json:"db_max_idle_conns" yaml:"db_max_idle_conns" toml:"db_max_idle_conns"`
+
+	// Backups of SQLite databases
+	BackupDir       string `json:"backup_dir" yaml:"backup_dir" toml:"backup_dir"`
+	BackupRetention int    `json:"backup_retention" yaml:"backup_retention" toml:"backup_retention"`
+
+	// Free disk space, in MiB, below which the server reports itself not ready
+	MinFreeSpaceMB int `json:"min_free_space_mb" yaml:"min_free_space_mb" toml:"min_free_space_mb"`
+
+	// OpenTelemetry trace export, off unless an exporter is chosen
+	TraceExporter string `json:"trace_exporter" yaml:"trace_exporter" toml:"trace_exporter"`
+	TraceEndpoint string `json:"trace_endpoint" yaml:"trace_endpoint" toml:"trace_endpoint"`
+
+	// Requests per minute allowed to each client, or 0 for no limit, and the
+	// largest request body accepted outside media uploads. Clients sending
+	// one of the API keys are told apart by key, and the rest by IP address,
+	// taken from X-Forwarded-For only when the request comes from one of the
+	// trusted proxies.
+	RateLimitRead  int      `json:"rate_limit_read" yaml:"rate_limit_read" toml:"rate_limit_read"`
+	RateLimitWrite int      `json:"rate_limit_write" yaml:"rate_limit_write" toml:"rate_limit_write"`
+	RateLimitAdmin int      `json:"rate_limit_admin" yaml:"rate_limit_admin" toml:"rate_limit_admin"`
+	TrustedProxies []string `json:"trusted_proxies" yaml:"trusted_proxies" toml:"trusted_proxies"`
+	APIKeys        []string `json:"api_keys" yaml:"api_keys" toml:"api_keys"`
+	MaxBodyBytes   int      `json:"max_body_bytes" yaml:"max_body_bytes" toml:"max_body_bytes"`
+}
+
+// LogLevels lists the accepted log levels
+var LogLevels = []string{"debug", "info", "warn", "error"}
+
+// LogFormats lists the accepted log output formats
+var LogFormats = []string{"text", "json"}
+
+// TraceExporters lists the accepted trace exporters
+var TraceExporters = []string{"none", "stdout", "otlp"}
+
+// GinModes lists the accepted Gin modes
+var GinModes = []string{"debug", "release", "test"}
+
+// SQLiteJournalModes lists the accepted SQLite journal modes
+var SQLiteJournalModes = []string{"WAL", "DELETE", "TRUNCATE", "PERSIST", "MEMORY", "OFF"}
+
+// SQLiteSynchronousLevels lists the accepted SQLite synchronous levels
+var SQLiteSynchronousLevels = []string{"OFF", "NORMAL", "FULL", "EXTRA"}
+
+// Default returns the settings used when nothing else is configured
+func Default() *Config {
+	return &Config{
+		ListenAddr:   ":8080",
+		DrainTimeout: Duration(15 * time.Second),
+		DBPath:       "words.db",
+		SeedPack:     seeds.Default,
+		CORSOrigins:  []string{"*"},
+		LogLevel:     "info",
+		LogFormat:    "text",
+		MediaDir:     "media",
+		GinMode:      "debug",
+
+		SQLiteJournalMode: "WAL",
+		SQLiteSynchronous: "NORMAL",
+		SQLiteBusyTimeout: Duration(5 * time.Second),
+		DBMaxOpenConns:    10,
+		DBMaxIdleConns:    10,
+
+		BackupDir:       "backups",
+		BackupRetention: 10,
+
+		MinFreeSpaceMB: 100,
+
+		TraceExporter: "none",
+
+		RateLimitRead:  600,
+		RateLimitWrite: 120,
+		RateLimitAdmin: 10,
+		MaxBodyBytes:   1 << 20,
+	}
+}
+
+// Duration is a time.Duration written as a string such as "15s" in config
+// files, environment variables and flags
+type Duration time.Duration
+
+// MarshalText formats the duration like time.Duration.String
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText parses a duration string such as "15s"
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// setting describes how one field is read from the environment and flags.
+// target returns a pointer to a string, []string, int or Duration field.
+type setting struct {
+	name   string
+	usage  string
+	target func(*Config) interface{}
+}
+
+var settings = []setting{
+	{"listen-addr", "address the HTTP server listens on", func(c *Config) interface{} { return &c.ListenAddr }},
+	{"drain-timeout", "how long to wait for in-flight requests on shutdown, e.g. 15s", func(c *Config) interface{} { return &c.DrainTimeout }},
+	{"db-path", "SQLite database file, or a postgres:// URL", func(c *Config) interface{} { return &c.DBPath }},
+	{"migrations-dir", "directory of SQL migrations to use instead of the embedded ones", func(c *Config) interface{} { return &c.MigrationsDir }},
+	{"seed-file", "SQL file seeding a new database instead of the seed pack", func(c *Config) interface{} { return &c.SeedFile }},
+	{"seed-pack", "embedded seed pack new databases and full resets are filled with, or none", func(c *Config) interface{} { return &c.SeedPack }},
+	{"cors-origins", "comma-separated origins allowed by CORS, or *", func(c *Config) interface{} { return &c.CORSOrigins }},
+	{"log-level", "log level: debug, info, warn or error", func(c *Config) interface{} { return &c.LogLevel }},
+	{"log-format", "log output format: text or json", func(c *Config) interface{} { return &c.LogFormat }},
+	{"media-dir", "directory where uploaded media is stored", func(c *Config) interface{} { return &c.MediaDir }},
+	{"gin-mode", "Gin mode: debug, release or test", func(c *Config) interface{} { return &c.GinMode }},
+	{"sqlite-journal-mode", "SQLite journal mode, e.g. WAL", func(c *Config) interface{} { return &c.SQLiteJournalMode }},
+	{"sqlite-synchronous", "SQLite synchronous level: OFF, NORMAL, FULL or EXTRA", func(c *Config) interface{} { return &c.SQLiteSynchronous }},
+	{"sqlite-busy-timeout", "how long SQLite waits for a lock before failing, e.g. 5s", func(c *Config) interface{} { return &c.SQLiteBusyTimeout }},
+	{"db-max-open-conns", "maximum open database connections used for reads", func(c *Config) interface{} { return &c.DBMaxOpenConns }},
+	{"db-max-idle-conns", "maximum idle database connections kept for reads", func(c *Config) interface{} { return &c.DBMaxIdleConns }},
+	{"backup-dir", "directory where database backups are stored", func(c *Config) interface{} { return &c.BackupDir }},
+	{"backup-retention", "number of database backups to keep, or 0 to keep all", func(c *Config) interface{} { return &c.BackupRetention }},
+	{"min-free-space-mb", "free disk space in MiB below which /readyz reports the server degraded", func(c *Config) interface{} { return &c.MinFreeSpaceMB }},
+	{"trace-exporter", "where traces are sent: none, stdout or otlp", func(c *Config) interface{} { return &c.TraceExporter }},
+	{"trace-endpoint", "OTLP/HTTP URL traces are sent to, e.g. http://localhost:4318/v1/traces", func(c *Config) interface{} { return &c.TraceEndpoint }},
+	{"rate-limit-read", "GET requests per minute allowed to each client, or 0 for no limit", func(c *Config) interface{} { return &c.RateLimitRead }},
+	{"rate-limit-write", "POST, PUT and DELETE requests per minute allowed to each client, or 0 for no limit", func(c *Config) interface{} { return &c.RateLimitWrite }},
+	{"rate-limit-admin", "system management requests per minute allowed to each client, or 0 for no limit", func(c *Config) interface{} { return &c.RateLimitAdmin }},
+	{"trusted-proxies", "comma-separated IP addresses or CIDR ranges of proxies whose X-Forwarded-For is believed", func(c *Config) interface{} { return &c.TrustedProxies }},
+	{"api-keys", "comma-separated API keys whose clients are rate limited by key rather than IP address", func(c *Config) interface{} { return &c.APIKeys }},
+	{"max-body-bytes", "largest request body accepted, except for media uploads", func(c *Config) interface{} { return &c.MaxBodyBytes }},
+}
+
+// envName returns the environment variable for a setting, e.g. LANGPORTAL_DB_PATH
+func (s setting) envName() string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(s.name, "-", "_"))
+}
+
+func (s setting) set(cfg *Config, value string) error {
+	switch target := s.target(cfg).(type) {
+	case *string:
+		*target = value
+	case *[]string:
+		*target = splitList(value)
+	case *int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %v", s.name, value, err)
+		}
+		*target = n
+	case *Duration:
+		if err := target.UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("invalid %s %q: %v", s.name, value, err)
+		}
+	}
+	return nil
+}
+
+// Load resolves the configuration from command line arguments, the
+// environment and the config file named by -config or LANGPORTAL_CONFIG
+func Load(args []string, getenv func(string) string) (*Config, error) {
+	return LoadFlags(flag.NewFlagSet("langportal", flag.ContinueOnError), args, getenv)
+}
+
+// LoadFlags is Load with the settings flags added to fs, so commands can
+// define flags of their own. Arguments after the flags are left in fs.Args().
+func LoadFlags(fs *flag.FlagSet, args []string, getenv func(string) string) (*Config, error) {
+	configFile := fs.String("config", getenv(EnvPrefix+"CONFIG"), "path to a YAML or TOML config file")
+	flags := make(map[string]*string, len(settings))
+	for _, s := range settings {
+		flags[s.name] = fs.String(s.name, "", s.usage+" (env "+s.envName()+")")
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range settings {
+		if value := getenv(s.envName()); value != "" {
+			if err := s.set(cfg, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Only flags given explicitly override the other sources
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if s.name == f.Name && err == nil {
+				err = s.set(cfg, *flags[s.name])
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile merges settings from a YAML or TOML file, chosen by extension.
+// Unknown keys are rejected so that typos do not go unnoticed.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to parse config file %s: %v", path, err)
+		}
+	case ".toml":
+		decoder := toml.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(c); err != nil {
+			return fmt.Errorf("failed to parse config file %s: %v", path, err)
+		}
+	default:
+		return fmt.Errorf("unsupported config file format %q: use .yaml, .yml or .toml", filepath.Ext(path))
+	}
+	return nil
+}
+
+// Validate checks that every setting has a usable value
+func (c *Config) Validate() error {
+	var errs []error
+
+	if _, _, err := net.SplitHostPort(c.ListenAddr); err != nil {
+		errs = append(errs, fmt.Errorf("listen_addr %q is not a valid host:port address", c.ListenAddr))
+	}
+	if c.DrainTimeout <= 0 {
+		errs = append(errs, errors.New("drain_timeout must be positive"))
+	}
+	if strings.TrimSpace(c.DBPath) == "" {
+		errs = append(errs, errors.New("db_path must not be empty"))
+	}
+	if _, err := seeds.Load(c.SeedPack); err != nil {
+		errs = append(errs, fmt.Errorf("seed_pack %q must be one of %s, %s", c.SeedPack, strings.Join(seeds.Names(), ", "), seeds.None))
+	}
+	if strings.TrimSpace(c.MediaDir) == "" {
+		errs = append(errs, errors.New("media_dir must not be empty"))
+	}
+	if !contains(LogLevels, c.LogLevel) {
+		errs = append(errs, fmt.Errorf("log_level %q must be one of %s", c.LogLevel, strings.Join(LogLevels, ", ")))
+	}
+	if !contains(LogFormats, c.LogFormat) {
+		errs = append(errs, fmt.Errorf("log_format %q must be one of %s", c.LogFormat, strings.Join(LogFormats, ", ")))
+	}
+	if !contains(GinModes, c.GinMode) {
+		errs = append(errs, fmt.Errorf("gin_mode %q must be one of %s", c.GinMode, strings.Join(GinModes, ", ")))
+	}
+	if !contains(SQLiteJournalModes, strings.ToUpper(c.SQLiteJournalMode)) {
+		errs = append(errs, fmt.Errorf("sqlite_journal_mode %q must be one of %s", c.SQLiteJournalMode, strings.Join(SQLiteJournalModes, ", ")))
+	}
+	if !contains(SQLiteSynchronousLevels, strings.ToUpper(c.SQLiteSynchronous)) {
+		errs = append(errs, fmt.Errorf("sqlite_synchronous %q must be one of %s", c.SQLiteSynchronous, strings.Join(SQLiteSynchronousLevels, ", ")))
+	}
+	if c.SQLiteBusyTimeout < 0 {
+		errs = append(errs, errors.New("sqlite_busy_timeout must not be negative"))
+	}
+	if c.DBMaxOpenConns < 1 {
+		errs = append(errs, errors.New("db_max_open_conns must be at least 1"))
+	}
+	if c.DBMaxIdleConns < 0 {
+		errs = append(errs, errors.New("db_max_idle_conns must not be negative"))
+	}
+	if strings.TrimSpace(c.BackupDir) == "" {
+		errs = append(errs, errors.New("backup_dir must not be empty"))
+	}
+	if c.BackupRetention < 0 {
+		errs = append(errs, errors.New("backup_retention must not be negative"))
+	}
+	if c.MinFreeSpaceMB < 0 {
+		errs = append(errs, errors.New("min_free_space_mb must not be negative"))
+	}
+	if !contains(TraceExporters, c.TraceExporter) {
+		errs = append(errs, fmt.Errorf("trace_exporter %q must be one of %s", c.TraceExporter, strings.Join(TraceExporters, ", ")))
+	}
+	if c.RateLimitRead < 0 || c.RateLimitWrite < 0 || c.RateLimitAdmin < 0 {
+		errs = append(errs, errors.New("rate_limit_read, rate_limit_write and rate_limit_admin must not be negative"))
+	}
+	if c.MaxBodyBytes < 1 {
+		errs = append(errs, errors.New("max_body_bytes must be at least 1"))
+	}
+	if c.TraceEndpoint != "" {
+		u, err := url.Parse(c.TraceEndpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("trace_endpoint %q must be an http(s) URL", c.TraceEndpoint))
+		}
+	}
+	for _, proxy := range c.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				errs = append(errs, fmt.Errorf("trusted_proxies entry %q must be an IP address or CIDR range", proxy))
+			}
+		}
+	}
+	for _, key := range c.APIKeys {
+		if strings.TrimSpace(key) == "" {
+			errs = append(errs, errors.New("api_keys entries must not be empty"))
+			break
+		}
+	}
+	for _, origin := range c.CORSOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			errs = append(errs, fmt.Errorf("cors_origins entry %q must be * or an http(s) origin such as https://example.com", origin))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Redacted returns a copy of the configuration that is safe to expose,
+// with credentials removed from connection strings and API keys hidden
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redacted.CORSOrigins = append([]string{}, c.CORSOrigins...)
+	redacted.TrustedProxies = append([]string{}, c.TrustedProxies...)
+	redacted.APIKeys = make([]string, len(c.APIKeys))
+	for i := range c.APIKeys {
+		redacted.APIKeys[i] = "REDACTED"
+	}
+	redacted.DBPath = redactDSN(c.DBPath)
+	redacted.TraceEndpoint = redactDSN(c.TraceEndpoint)
+	return &redacted
+}
+
+// sensitiveParams are connection string parameters whose values are hidden
+var sensitiveParams = []string{"pass", "password", "secret", "token", "key"}
+
+// redactDSN hides passwords in URL user info and in query parameters such
+// as SQLite's _auth_pass
+func redactDSN(dsn string) string {
+	base, query, hasQuery := strings.Cut(dsn, "?")
+
+	if u, err := url.Parse(base); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "REDACTED")
+			base = u.String()
+		}
+	}
+
+	if !hasQuery {
+		return base
+	}
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		name, _, _ := strings.Cut(param, "=")
+		for _, sensitive := range sensitiveParams {
+			if strings.Contains(strings.ToLower(name), sensitive) {
+				params[i] = name + "=REDACTED"
+				break
+			}
+		}
+	}
+	return base + "?" + strings.Join(params, "&")
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
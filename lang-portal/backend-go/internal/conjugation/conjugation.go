@@ -0,0 +1,224 @@
+package conjugation
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+)
+
+// Tense identifies a Spanish verb tense
+type Tense string
+
+const (
+	Present            Tense = "present"
+	Preterite          Tense = "preterite"
+	Imperfect          Tense = "imperfect"
+	Future             Tense = "future"
+	Conditional        Tense = "conditional"
+	PresentSubjunctive Tense = "present_subjunctive"
+)
+
+// Person identifies a grammatical person and number
+type Person string
+
+const (
+	Yo       Person = "yo"
+	Tu       Person = "tu"
+	El       Person = "el"
+	Nosotros Person = "nosotros"
+	Vosotros Person = "vosotros"
+	Ellos    Person = "ellos"
+)
+
+// Tenses lists every supported tense in display order
+var Tenses = []Tense{Present, Preterite, Imperfect, Future, Conditional, PresentSubjunctive}
+
+// Persons lists every supported person in display order
+var Persons = []Person{Yo, Tu, El, Nosotros, Vosotros, Ellos}
+
+// ErrNotVerb is returned when a word does not look like a Spanish infinitive
+var ErrNotVerb = errors.New("word is not a Spanish infinitive")
+
+// Table holds the conjugated forms of a verb keyed by tense and person
+type Table map[Tense]map[Person]string
+
+// Forms is a set of six conjugated forms ordered like Persons
+type Forms [6]string
+
+// regularEndings holds the endings appended to the stem for each verb class
+var regularEndings = map[string]map[Tense]Forms{
+	"ar": {
+		Present:            {"o", "as", "a", "amos", "áis", "an"},
+		Preterite:          {"é", "aste", "ó", "amos", "asteis", "aron"},
+		Imperfect:          {"aba", "abas", "aba", "ábamos", "abais", "aban"},
+		PresentSubjunctive: {"e", "es", "e", "emos", "éis", "en"},
+	},
+	"er": {
+		Present:            {"o", "es", "e", "emos", "éis", "en"},
+		Preterite:          {"í", "iste", "ió", "imos", "isteis", "ieron"},
+		Imperfect:          {"ía", "ías", "ía", "íamos", "íais", "ían"},
+		PresentSubjunctive: {"a", "as", "a", "amos", "áis", "an"},
+	},
+	"ir": {
+		Present:            {"o", "es", "e", "imos", "ís", "en"},
+		Preterite:          {"í", "iste", "ió", "imos", "isteis", "ieron"},
+		Imperfect:          {"ía", "ías", "ía", "íamos", "íais", "ían"},
+		PresentSubjunctive: {"a", "as", "a", "amos", "áis", "an"},
+	},
+}
+
+// futureEndings and conditionalEndings attach to the future stem, which is
+// the infinitive itself for regular verbs
+var (
+	futureEndings      = Forms{"é", "ás", "á", "emos", "éis", "án"}
+	conditionalEndings = Forms{"ía", "ías", "ía", "íamos", "íais", "ían"}
+)
+
+// Conjugate generates the full conjugation table for an infinitive, applying
+// stem and spelling changes to the regular forms and any irregular overrides
+// on top of them
+func Conjugate(infinitive string) (Table, error) {
+	infinitive = strings.ToLower(strings.TrimSpace(infinitive))
+	if !isInfinitive(infinitive) {
+		return nil, ErrNotVerb
+	}
+
+	class := infinitive[len(infinitive)-2:]
+	stem := infinitive[:len(infinitive)-2]
+	override := irregulars[infinitive]
+	change, changes := stemChanges[infinitive]
+
+	futureStem := infinitive
+	if override.FutureStem != "" {
+		futureStem = override.FutureStem
+	}
+
+	table := make(Table, len(Tenses))
+	for _, tense := range Tenses {
+		var forms Forms
+		switch tense {
+		case Future:
+			forms = attach(futureStem, futureEndings)
+		case Conditional:
+			forms = attach(futureStem, conditionalEndings)
+		default:
+			for i, ending := range regularEndings[class][tense] {
+				s := stem
+				if changes {
+					s = change.apply(s, class, tense, i)
+				}
+				forms[i] = spell(s, class, ending) + ending
+			}
+		}
+		if irregular, ok := override.Tenses[tense]; ok {
+			forms = irregular
+		}
+
+		table[tense] = make(map[Person]string, len(Persons))
+		for i, person := range Persons {
+			table[tense][person] = forms[i]
+		}
+	}
+
+	return table, nil
+}
+
+// IsVerb reports whether a word is a verb: its English gloss reads "to ..."
+// and its Spanish is a single infinitive. Many nouns, such as mujer or
+// lugar, end like infinitives, so the ending alone is not enough.
+func IsVerb(spanish, english string) bool {
+	english = strings.ToLower(strings.TrimSpace(english))
+	return strings.HasPrefix(english, "to ") && isInfinitive(strings.ToLower(strings.TrimSpace(spanish)))
+}
+
+// isInfinitive reports whether the word is shaped like a single Spanish
+// infinitive
+func isInfinitive(word string) bool {
+	if _, ok := irregulars[word]; ok {
+		return true
+	}
+	if strings.ContainsAny(word, " -") || len(word) < 3 {
+		return false
+	}
+	return strings.HasSuffix(word, "ar") || strings.HasSuffix(word, "er") || strings.HasSuffix(word, "ir")
+}
+
+// spell returns the stem with the spelling change that keeps the sound of
+// its last consonant before the ending, such as llegar → llegué or
+// coger → cojo
+func spell(stem, class, ending string) string {
+	soft := strings.HasPrefix(ending, "e") || strings.HasPrefix(ending, "é")
+	hard := strings.HasPrefix(ending, "a") || strings.HasPrefix(ending, "á") ||
+		strings.HasPrefix(ending, "o") || strings.HasPrefix(ending, "ó")
+	switch {
+	case class == "ar" && soft:
+		switch {
+		case strings.HasSuffix(stem, "gu"):
+			return strings.TrimSuffix(stem, "gu") + "gü"
+		case strings.HasSuffix(stem, "c"):
+			return strings.TrimSuffix(stem, "c") + "qu"
+		case strings.HasSuffix(stem, "g"):
+			return stem + "u"
+		case strings.HasSuffix(stem, "z"):
+			return strings.TrimSuffix(stem, "z") + "c"
+		}
+	case class != "ar" && hard:
+		switch {
+		case strings.HasSuffix(stem, "gu"):
+			return strings.TrimSuffix(stem, "u")
+		case strings.HasSuffix(stem, "g"):
+			return strings.TrimSuffix(stem, "g") + "j"
+		case strings.HasSuffix(stem, "ec"), strings.HasSuffix(stem, "oc"), strings.HasSuffix(stem, "uc"):
+			// conocer → conozco, conducir → conduzco
+			return strings.TrimSuffix(stem, "c") + "zc"
+		case strings.HasSuffix(stem, "c"):
+			return strings.TrimSuffix(stem, "c") + "z"
+		}
+	}
+	return stem
+}
+
+// IsIrregular reports whether the infinitive has an entry in the override table
+func IsIrregular(infinitive string) bool {
+	_, ok := irregulars[strings.ToLower(strings.TrimSpace(infinitive))]
+	return ok
+}
+
+// ParseTense converts a string into a supported Tense
+func ParseTense(s string) (Tense, bool) {
+	for _, tense := range Tenses {
+		if string(tense) == s {
+			return tense, true
+		}
+	}
+	return "", false
+}
+
+// ParsePerson converts a string into a supported Person
+func ParsePerson(s string) (Person, bool) {
+	for _, person := range Persons {
+		if string(person) == s {
+			return person, true
+		}
+	}
+	return "", false
+}
+
+// RandomPrompt picks a random tense and person for a drill question
+func RandomPrompt() (Tense, Person) {
+	return Tenses[rand.Intn(len(Tenses))], Persons[rand.Intn(len(Persons))]
+}
+
+// Check compares a drill answer against the expected form, ignoring case
+// and surrounding whitespace but not accents
+func Check(expected, answer string) bool {
+	return strings.EqualFold(strings.TrimSpace(expected), strings.TrimSpace(answer))
+}
+
+func attach(stem string, endings Forms) Forms {
+	var forms Forms
+	for i, ending := range endings {
+		forms[i] = stem + ending
+	}
+	return forms
+}
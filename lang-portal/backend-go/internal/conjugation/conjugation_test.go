@@ -0,0 +1,200 @@
+package conjugation
+
+import "testing"
+
+func TestConjugateRegular(t *testing.T) {
+	tests := []struct {
+		infinitive string
+		tense      Tense
+		person     Person
+		expected   string
+	}{
+		{"hablar", Present, Yo, "hablo"},
+		{"hablar", Present, Vosotros, "habláis"},
+		{"hablar", Preterite, El, "habló"},
+		{"hablar", Imperfect, Nosotros, "hablábamos"},
+		{"hablar", Future, Tu, "hablarás"},
+		{"hablar", Conditional, Ellos, "hablarían"},
+		{"hablar", PresentSubjunctive, Yo, "hable"},
+		{"comer", Present, Tu, "comes"},
+		{"comer", Preterite, Ellos, "comieron"},
+		{"comer", PresentSubjunctive, Nosotros, "comamos"},
+		{"vivir", Present, Nosotros, "vivimos"},
+		{"vivir", Present, Vosotros, "vivís"},
+		{"vivir", Imperfect, Yo, "vivía"},
+	}
+
+	for _, tt := range tests {
+		table, err := Conjugate(tt.infinitive)
+		if err != nil {
+			t.Fatalf("Conjugate(%q) returned error: %v", tt.infinitive, err)
+		}
+		if got := table[tt.tense][tt.person]; got != tt.expected {
+			t.Errorf("%s %s %s: expected %q, got %q", tt.infinitive, tt.tense, tt.person, tt.expected, got)
+		}
+	}
+}
+
+func TestConjugateIrregular(t *testing.T) {
+	tests := []struct {
+		infinitive string
+		tense      Tense
+		person     Person
+		expected   string
+	}{
+		{"ser", Present, Yo, "soy"},
+		{"ir", Present, Nosotros, "vamos"},
+		{"ir", Future, Yo, "iré"},
+		{"tener", Present, Yo, "tengo"},
+		{"tener", Future, El, "tendrá"},
+		{"tener", Conditional, Tu, "tendrías"},
+		{"tener", Imperfect, Yo, "tenía"},
+		{"hacer", Preterite, El, "hizo"},
+		{"estar", Imperfect, Ellos, "estaban"},
+	}
+
+	for _, tt := range tests {
+		table, err := Conjugate(tt.infinitive)
+		if err != nil {
+			t.Fatalf("Conjugate(%q) returned error: %v", tt.infinitive, err)
+		}
+		if got := table[tt.tense][tt.person]; got != tt.expected {
+			t.Errorf("%s %s %s: expected %q, got %q", tt.infinitive, tt.tense, tt.person, tt.expected, got)
+		}
+	}
+
+	if !IsIrregular("Tener") {
+		t.Error("Expected 'Tener' to be irregular")
+	}
+	if IsIrregular("hablar") {
+		t.Error("Expected 'hablar' to be regular")
+	}
+}
+
+func TestConjugateSpellingChanges(t *testing.T) {
+	tests := []struct {
+		infinitive string
+		tense      Tense
+		person     Person
+		expected   string
+	}{
+		{"llegar", Preterite, Yo, "llegué"},
+		{"llegar", PresentSubjunctive, Nosotros, "lleguemos"},
+		{"llegar", Preterite, El, "llegó"},
+		{"buscar", Preterite, Yo, "busqué"},
+		{"buscar", PresentSubjunctive, Ellos, "busquen"},
+		{"cruzar", Preterite, Yo, "crucé"},
+		{"cruzar", PresentSubjunctive, Tu, "cruces"},
+		{"averiguar", Preterite, Yo, "averigüé"},
+		{"coger", Present, Yo, "cojo"},
+		{"coger", Present, Tu, "coges"},
+		{"dirigir", PresentSubjunctive, El, "dirija"},
+		{"vencer", Present, Yo, "venzo"},
+		{"conocer", Present, Yo, "conozco"},
+		{"conducir", PresentSubjunctive, Nosotros, "conduzcamos"},
+	}
+
+	for _, tt := range tests {
+		table, err := Conjugate(tt.infinitive)
+		if err != nil {
+			t.Fatalf("Conjugate(%q) returned error: %v", tt.infinitive, err)
+		}
+		if got := table[tt.tense][tt.person]; got != tt.expected {
+			t.Errorf("%s %s %s: expected %q, got %q", tt.infinitive, tt.tense, tt.person, tt.expected, got)
+		}
+	}
+}
+
+func TestConjugateStemChanges(t *testing.T) {
+	tests := []struct {
+		infinitive string
+		tense      Tense
+		person     Person
+		expected   string
+	}{
+		{"pensar", Present, Yo, "pienso"},
+		{"pensar", Present, Nosotros, "pensamos"},
+		{"pensar", PresentSubjunctive, Ellos, "piensen"},
+		{"pensar", Preterite, El, "pensó"},
+		{"entender", Present, Tu, "entiendes"},
+		{"volver", Present, El, "vuelve"},
+		{"jugar", Present, Yo, "juego"},
+		{"jugar", Preterite, Yo, "jugué"},
+		{"empezar", PresentSubjunctive, Yo, "empiece"},
+		{"almorzar", PresentSubjunctive, Tu, "almuerces"},
+		{"dormir", Present, Ellos, "duermen"},
+		{"dormir", Present, Vosotros, "dormís"},
+		{"dormir", Preterite, El, "durmió"},
+		{"dormir", PresentSubjunctive, Nosotros, "durmamos"},
+		{"sentir", Preterite, Ellos, "sintieron"},
+		{"sentir", PresentSubjunctive, Vosotros, "sintáis"},
+		{"pedir", Present, Yo, "pido"},
+		{"pedir", Preterite, El, "pidió"},
+		{"pedir", Imperfect, Yo, "pedía"},
+		{"seguir", Present, Yo, "sigo"},
+		{"seguir", Present, Tu, "sigues"},
+		{"poder", Present, Yo, "puedo"},
+	}
+
+	for _, tt := range tests {
+		table, err := Conjugate(tt.infinitive)
+		if err != nil {
+			t.Fatalf("Conjugate(%q) returned error: %v", tt.infinitive, err)
+		}
+		if got := table[tt.tense][tt.person]; got != tt.expected {
+			t.Errorf("%s %s %s: expected %q, got %q", tt.infinitive, tt.tense, tt.person, tt.expected, got)
+		}
+	}
+}
+
+func TestIsVerb(t *testing.T) {
+	tests := []struct {
+		spanish, english string
+		expected         bool
+	}{
+		{"llegar", "to arrive", true},
+		{"ser", "To be", true},
+		{"mujer", "woman", false},
+		{"lugar", "place", false},
+		{"hola", "to greet", false},
+		{"buenos días", "good morning", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsVerb(tt.spanish, tt.english); got != tt.expected {
+			t.Errorf("IsVerb(%q, %q) = %v, expected %v", tt.spanish, tt.english, got, tt.expected)
+		}
+	}
+}
+
+func TestConjugateNotVerb(t *testing.T) {
+	for _, word := range []string{"hola", "por favor", "buenos días", "", "ar"} {
+		if _, err := Conjugate(word); err != ErrNotVerb {
+			t.Errorf("Conjugate(%q): expected ErrNotVerb, got %v", word, err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if !Check("hablo", " Hablo ") {
+		t.Error("Expected answer to match ignoring case and whitespace")
+	}
+	if Check("habló", "hablo") {
+		t.Error("Expected missing accent to be marked incorrect")
+	}
+}
+
+func TestParse(t *testing.T) {
+	if tense, ok := ParseTense("preterite"); !ok || tense != Preterite {
+		t.Errorf("Expected preterite, got %q", tense)
+	}
+	if _, ok := ParseTense("pluperfect"); ok {
+		t.Error("Expected unsupported tense to be rejected")
+	}
+	if person, ok := ParsePerson("nosotros"); !ok || person != Nosotros {
+		t.Errorf("Expected nosotros, got %q", person)
+	}
+	if _, ok := ParsePerson("usted"); ok {
+		t.Error("Expected unsupported person to be rejected")
+	}
+}
@@ -0,0 +1,130 @@
+package conjugation
+
+// Override replaces generated forms for an irregular verb. FutureStem is used
+// for both the future and conditional tenses, and any tense listed in Tenses
+// replaces the generated forms entirely.
+type Override struct {
+	FutureStem string
+	Tenses     map[Tense]Forms
+}
+
+// irregulars is the override table for common irregular verbs
+var irregulars = map[string]Override{
+	"ser": {
+		Tenses: map[Tense]Forms{
+			Present:            {"soy", "eres", "es", "somos", "sois", "son"},
+			Preterite:          {"fui", "fuiste", "fue", "fuimos", "fuisteis", "fueron"},
+			Imperfect:          {"era", "eras", "era", "éramos", "erais", "eran"},
+			PresentSubjunctive: {"sea", "seas", "sea", "seamos", "seáis", "sean"},
+		},
+	},
+	"estar": {
+		Tenses: map[Tense]Forms{
+			Present:            {"estoy", "estás", "está", "estamos", "estáis", "están"},
+			Preterite:          {"estuve", "estuviste", "estuvo", "estuvimos", "estuvisteis", "estuvieron"},
+			PresentSubjunctive: {"esté", "estés", "esté", "estemos", "estéis", "estén"},
+		},
+	},
+	"ir": {
+		Tenses: map[Tense]Forms{
+			Present:            {"voy", "vas", "va", "vamos", "vais", "van"},
+			Preterite:          {"fui", "fuiste", "fue", "fuimos", "fuisteis", "fueron"},
+			Imperfect:          {"iba", "ibas", "iba", "íbamos", "ibais", "iban"},
+			PresentSubjunctive: {"vaya", "vayas", "vaya", "vayamos", "vayáis", "vayan"},
+		},
+	},
+	"haber": {
+		FutureStem: "habr",
+		Tenses: map[Tense]Forms{
+			Present:            {"he", "has", "ha", "hemos", "habéis", "han"},
+			Preterite:          {"hube", "hubiste", "hubo", "hubimos", "hubisteis", "hubieron"},
+			PresentSubjunctive: {"haya", "hayas", "haya", "hayamos", "hayáis", "hayan"},
+		},
+	},
+	"tener": {
+		FutureStem: "tendr",
+		Tenses: map[Tense]Forms{
+			Present:            {"tengo", "tienes", "tiene", "tenemos", "tenéis", "tienen"},
+			Preterite:          {"tuve", "tuviste", "tuvo", "tuvimos", "tuvisteis", "tuvieron"},
+			PresentSubjunctive: {"tenga", "tengas", "tenga", "tengamos", "tengáis", "tengan"},
+		},
+	},
+	"hacer": {
+		FutureStem: "har",
+		Tenses: map[Tense]Forms{
+			Present:            {"hago", "haces", "hace", "hacemos", "hacéis", "hacen"},
+			Preterite:          {"hice", "hiciste", "hizo", "hicimos", "hicisteis", "hicieron"},
+			PresentSubjunctive: {"haga", "hagas", "haga", "hagamos", "hagáis", "hagan"},
+		},
+	},
+	"poder": {
+		FutureStem: "podr",
+		Tenses: map[Tense]Forms{
+			Present:            {"puedo", "puedes", "puede", "podemos", "podéis", "pueden"},
+			Preterite:          {"pude", "pudiste", "pudo", "pudimos", "pudisteis", "pudieron"},
+			PresentSubjunctive: {"pueda", "puedas", "pueda", "podamos", "podáis", "puedan"},
+		},
+	},
+	"querer": {
+		FutureStem: "querr",
+		Tenses: map[Tense]Forms{
+			Present:            {"quiero", "quieres", "quiere", "queremos", "queréis", "quieren"},
+			Preterite:          {"quise", "quisiste", "quiso", "quisimos", "quisisteis", "quisieron"},
+			PresentSubjunctive: {"quiera", "quieras", "quiera", "queramos", "queráis", "quieran"},
+		},
+	},
+	"decir": {
+		FutureStem: "dir",
+		Tenses: map[Tense]Forms{
+			Present:            {"digo", "dices", "dice", "decimos", "decís", "dicen"},
+			Preterite:          {"dije", "dijiste", "dijo", "dijimos", "dijisteis", "dijeron"},
+			PresentSubjunctive: {"diga", "digas", "diga", "digamos", "digáis", "digan"},
+		},
+	},
+	"venir": {
+		FutureStem: "vendr",
+		Tenses: map[Tense]Forms{
+			Present:            {"vengo", "vienes", "viene", "venimos", "venís", "vienen"},
+			Preterite:          {"vine", "viniste", "vino", "vinimos", "vinisteis", "vinieron"},
+			PresentSubjunctive: {"venga", "vengas", "venga", "vengamos", "vengáis", "vengan"},
+		},
+	},
+	"saber": {
+		FutureStem: "sabr",
+		Tenses: map[Tense]Forms{
+			Present:            {"sé", "sabes", "sabe", "sabemos", "sabéis", "saben"},
+			Preterite:          {"supe", "supiste", "supo", "supimos", "supisteis", "supieron"},
+			PresentSubjunctive: {"sepa", "sepas", "sepa", "sepamos", "sepáis", "sepan"},
+		},
+	},
+	"poner": {
+		FutureStem: "pondr",
+		Tenses: map[Tense]Forms{
+			Present:            {"pongo", "pones", "pone", "ponemos", "ponéis", "ponen"},
+			Preterite:          {"puse", "pusiste", "puso", "pusimos", "pusisteis", "pusieron"},
+			PresentSubjunctive: {"ponga", "pongas", "ponga", "pongamos", "pongáis", "pongan"},
+		},
+	},
+	"salir": {
+		FutureStem: "saldr",
+		Tenses: map[Tense]Forms{
+			Present:            {"salgo", "sales", "sale", "salimos", "salís", "salen"},
+			PresentSubjunctive: {"salga", "salgas", "salga", "salgamos", "salgáis", "salgan"},
+		},
+	},
+	"dar": {
+		Tenses: map[Tense]Forms{
+			Present:            {"doy", "das", "da", "damos", "dais", "dan"},
+			Preterite:          {"di", "diste", "dio", "dimos", "disteis", "dieron"},
+			PresentSubjunctive: {"dé", "des", "dé", "demos", "deis", "den"},
+		},
+	},
+	"ver": {
+		Tenses: map[Tense]Forms{
+			Present:            {"veo", "ves", "ve", "vemos", "veis", "ven"},
+			Preterite:          {"vi", "viste", "vio", "vimos", "visteis", "vieron"},
+			Imperfect:          {"veía", "veías", "veía", "veíamos", "veíais", "veían"},
+			PresentSubjunctive: {"vea", "veas", "vea", "veamos", "veáis", "vean"},
+		},
+	},
+}
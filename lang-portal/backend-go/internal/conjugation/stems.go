@@ -0,0 +1,81 @@
+package conjugation
+
+import "strings"
+
+// stemChange describes how the last stem vowel of a stem-changing verb
+// changes. strong applies to the stressed forms of the present tenses, weak
+// to the unstressed forms of -ir verbs, such as durmió and pidamos.
+type stemChange struct {
+	vowel  string
+	strong string
+	weak   string
+}
+
+var (
+	eToIE = stemChange{"e", "ie", "i"}
+	eToI  = stemChange{"e", "i", "i"}
+	oToUE = stemChange{"o", "ue", "u"}
+	uToUE = stemChange{"u", "ue", "u"}
+)
+
+// stemChanges lists common stem-changing verbs. Irregular verbs that change
+// their stem, such as poder and querer, have overrides instead.
+var stemChanges = map[string]stemChange{
+	"pensar":    eToIE,
+	"cerrar":    eToIE,
+	"empezar":   eToIE,
+	"comenzar":  eToIE,
+	"despertar": eToIE,
+	"sentar":    eToIE,
+	"entender":  eToIE,
+	"perder":    eToIE,
+	"encender":  eToIE,
+	"sentir":    eToIE,
+	"mentir":    eToIE,
+	"preferir":  eToIE,
+	"divertir":  eToIE,
+	"pedir":     eToI,
+	"repetir":   eToI,
+	"servir":    eToI,
+	"seguir":    eToI,
+	"vestir":    eToI,
+	"contar":    oToUE,
+	"costar":    oToUE,
+	"encontrar": oToUE,
+	"recordar":  oToUE,
+	"mostrar":   oToUE,
+	"probar":    oToUE,
+	"almorzar":  oToUE,
+	"acostar":   oToUE,
+	"soñar":     oToUE,
+	"volar":     oToUE,
+	"volver":    oToUE,
+	"mover":     oToUE,
+	"llover":    oToUE,
+	"dormir":    oToUE,
+	"morir":     oToUE,
+	"jugar":     uToUE,
+}
+
+// apply returns the stem used for the form of tense at index i of Persons
+func (c stemChange) apply(stem, class string, tense Tense, i int) string {
+	stressed := i != 3 && i != 4 // every person but nosotros and vosotros
+	switch {
+	case (tense == Present || tense == PresentSubjunctive) && stressed:
+		return c.replace(stem, c.strong)
+	case class == "ir" && tense == PresentSubjunctive:
+		return c.replace(stem, c.weak)
+	case class == "ir" && tense == Preterite && (i == 2 || i == 5):
+		return c.replace(stem, c.weak)
+	}
+	return stem
+}
+
+// replace swaps the last occurrence of the changing vowel in stem
+func (c stemChange) replace(stem, with string) string {
+	i := strings.LastIndex(stem, c.vowel)
+	if i < 0 {
+		return stem
+	}
+	return stem[:i] + with + stem[i+len(c.vowel):]
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+var (
+	// ErrBackupUnsupported is returned when backing up a database other than SQLite
+	ErrBackupUnsupported = errors.New("backups are only supported for SQLite databases")
+	// ErrSchemaMismatch is returned when restoring a backup made at another schema version
+	ErrSchemaMismatch = errors.New("backup schema version does not match the database")
+)
+
+// Backup copies the database into a new or empty SQLite file at path using
+// the online backup API, so the server keeps running while the copy is made.
+// The copy runs on the writer connection, holding off writes until it
+// completes.
+func (c *Conn) Backup(ctx context.Context, path string) error {
+	if c.dialect != SQLite {
+		return ErrBackupUnsupported
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+		return fmt.Errorf("backup %s already exists", path)
+	}
+
+	dst, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	if err := copyDatabase(ctx, dst, c.writer); err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+	return nil
+}
+
+// Restore replaces the contents of the database with the SQLite backup at
+// path. The backup must pass an integrity check and be at the database's
+// schema version. The contents are swapped in a single step on the writer
+// connection, and idle read connections are then closed so the pool reopens
+// them against the restored database.
+func (c *Conn) Restore(ctx context.Context, path string) error {
+	if c.dialect != SQLite {
+		return ErrBackupUnsupported
+	}
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+
+	src, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// Validate the backup before touching the database
+	var integrity string
+	if err := src.QueryRowContext(ctx, "PRAGMA quick_check").Scan(&integrity); err != nil {
+		return fmt.Errorf("failed to check backup: %w", err)
+	}
+	if integrity != "ok" {
+		return fmt.Errorf("backup failed integrity check: %s", integrity)
+	}
+	backupVersion, err := SchemaVersion(ctx, src, SQLite)
+	if err != nil {
+		return err
+	}
+	currentVersion, err := SchemaVersion(ctx, c.writer, SQLite)
+	if err != nil {
+		return err
+	}
+	if backupVersion != currentVersion {
+		return fmt.Errorf("%w: backup is at version %d, database at %d", ErrSchemaMismatch, backupVersion, currentVersion)
+	}
+
+	if err := copyDatabase(ctx, c.writer, src); err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+
+	if c.db != c.writer {
+		c.db.SetMaxIdleConns(0)
+		c.db.SetMaxIdleConns(c.maxIdle)
+	}
+	return nil
+}
+
+// copyDatabase copies every page of the src database into dst in one step
+func copyDatabase(ctx context.Context, dst, src *sql.DB) error {
+	dstConn, err := dst.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer dstConn.Close()
+	srcConn, err := src.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+
+	return dstConn.Raw(func(dstDriver interface{}) error {
+		return srcConn.Raw(func(srcDriver interface{}) error {
+			d, ok := dstDriver.(*sqlite3.SQLiteConn)
+			if !ok {
+				return ErrBackupUnsupported
+			}
+			s, ok := srcDriver.(*sqlite3.SQLiteConn)
+			if !ok {
+				return ErrBackupUnsupported
+			}
+
+			backup, err := d.Backup("main", s, "main")
+			if err != nil {
+				return err
+			}
+			if _, err := backup.Step(-1); err != nil {
+				backup.Finish()
+				return err
+			}
+			return backup.Finish()
+		})
+	})
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Querier runs queries written with ? placeholders in its dialect, tracing
+// each statement. It is implemented by Conn and Tx.
+type Querier interface {
+	Dialect() Dialect
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *Row
+}
+
+// Conn is a database handle that rebinds queries for its dialect. Queries
+// read through one pool, while statements and transactions go through the
+// writer, which may be a separate pool.
+type Conn struct {
+	db      *sql.DB
+	writer  *sql.DB
+	dialect Dialect
+	maxIdle int
+}
+
+// NewConn wraps a database handle of the given dialect, used for both reads
+// and writes
+func NewConn(conn *sql.DB, dialect Dialect) *Conn {
+	return &Conn{db: conn, writer: conn, dialect: dialect}
+}
+
+// DB returns the database handle writes go through
+func (c *Conn) DB() *sql.DB {
+	return c.writer
+}
+
+// Reader returns the database handle reads go through. It is the same as
+// DB unless writes have a pool of their own.
+func (c *Conn) Reader() *sql.DB {
+	return c.db
+}
+
+// Pools returns the connection pools by role: "read" and "write" when
+// writes have a pool of their own, and "read_write" otherwise
+func (c *Conn) Pools() map[string]*sql.DB {
+	if c.writer == c.db {
+		return map[string]*sql.DB{"read_write": c.db}
+	}
+	return map[string]*sql.DB{"read": c.db, "write": c.writer}
+}
+
+// Dialect returns the dialect queries are rebound for
+func (c *Conn) Dialect() Dialect {
+	return c.dialect
+}
+
+// ExecContext executes a statement without returning rows
+func (c *Conn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return traceExec(ctx, c.writer, c.dialect, query, args)
+}
+
+// QueryContext executes a query returning rows
+func (c *Conn) QueryContext(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	return traceQuery(ctx, c.db, c.dialect, query, args)
+}
+
+// QueryRowContext executes a query returning at most one row
+func (c *Conn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *Row {
+	return traceQueryRow(ctx, c.db, c.dialect, query, args)
+}
+
+// BeginTx starts a transaction
+func (c *Conn) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := c.writer.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{tx: tx, dialect: c.dialect}, nil
+}
+
+// Close closes the database
+func (c *Conn) Close() error {
+	if c.writer != c.db {
+		if err := c.writer.Close(); err != nil {
+			c.db.Close()
+			return err
+		}
+	}
+	return c.db.Close()
+}
+
+// Tx is a transaction that rebinds queries for its dialect
+type Tx struct {
+	tx      *sql.Tx
+	dialect Dialect
+}
+
+// Dialect returns the dialect queries are rebound for
+func (t *Tx) Dialect() Dialect {
+	return t.dialect
+}
+
+// ExecContext executes a statement without returning rows
+func (t *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return traceExec(ctx, t.tx, t.dialect, query, args)
+}
+
+// QueryContext executes a query returning rows
+func (t *Tx) QueryContext(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	return traceQuery(ctx, t.tx, t.dialect, query, args)
+}
+
+// QueryRowContext executes a query returning at most one row
+func (t *Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *Row {
+	return traceQueryRow(ctx, t.tx, t.dialect, query, args)
+}
+
+// Commit commits the transaction
+func (t *Tx) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback aborts the transaction
+func (t *Tx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// Insert executes an INSERT statement and returns the ID of the new row
+func Insert(ctx context.Context, q Querier, query string, args ...interface{}) (int64, error) {
+	if q.Dialect().Returning() {
+		var id int64
+		err := q.QueryRowContext(ctx, query+" RETURNING id", args...).Scan(&id)
+		return id, err
+	}
+
+	result, err := q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
@@ -2,32 +2,136 @@ package db
 
 import (
 	"database/sql"
-	_ "github.com/mattn/go-sqlite3"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
 )
 
-var db *sql.DB
+// Options tunes the connections opened by Connect
+type Options struct {
+	// JournalMode, Synchronous and BusyTimeout set the SQLite pragmas of the
+	// same names, and are ignored for PostgreSQL. Empty values keep SQLite's
+	// defaults.
+	JournalMode string
+	Synchronous string
+	BusyTimeout time.Duration
+	// MaxOpenConns and MaxIdleConns limit the connection pool used for reads
+	MaxOpenConns int
+	MaxIdleConns int
+}
+
+// Open opens and pings the database at dataSourceName, using the driver of
+// its dialect. SQLite connections enforce foreign keys, as PostgreSQL always does.
+func Open(dataSourceName string) (*sql.DB, error) {
+	dialect := DialectFor(dataSourceName)
+	if dialect == SQLite {
+		dataSourceName = withParam(dataSourceName, "_foreign_keys", "on")
+	}
+	return open(dialect, dataSourceName)
+}
+
+// Connect opens the database at dataSourceName for the server. SQLite
+// databases get a pool of connections for reads and a single connection
+// that serializes writes, which wait for the write lock when they begin
+// instead of failing with "database is locked" part way through.
+func Connect(dataSourceName string, opts Options) (*Conn, error) {
+	dialect := DialectFor(dataSourceName)
+	if dialect != SQLite {
+		conn, err := open(dialect, dataSourceName)
+		if err != nil {
+			return nil, err
+		}
+		conn.SetMaxOpenConns(opts.MaxOpenConns)
+		conn.SetMaxIdleConns(opts.MaxIdleConns)
+		return NewConn(conn, dialect), nil
+	}
+
+	dataSourceName = withParam(dataSourceName, "_foreign_keys", "on")
+	if opts.JournalMode != "" {
+		dataSourceName = withParam(dataSourceName, "_journal_mode", opts.JournalMode)
+	}
+	if opts.Synchronous != "" {
+		dataSourceName = withParam(dataSourceName, "_synchronous", opts.Synchronous)
+	}
+	if opts.BusyTimeout > 0 {
+		dataSourceName = withParam(dataSourceName, "_busy_timeout", strconv.FormatInt(opts.BusyTimeout.Milliseconds(), 10))
+	}
 
-func InitDB(dataSourceName string) error {
-	var err error
-	db, err = sql.Open("sqlite3", dataSourceName)
+	writer, err := open(dialect, withParam(dataSourceName, "_txlock", "immediate"))
 	if err != nil {
-		return err
+		return nil, err
 	}
+	writer.SetMaxOpenConns(1)
 
-	if err = db.Ping(); err != nil {
-		return err
+	reader, err := open(dialect, dataSourceName)
+	if err != nil {
+		writer.Close()
+		return nil, err
 	}
+	reader.SetMaxOpenConns(opts.MaxOpenConns)
+	reader.SetMaxIdleConns(opts.MaxIdleConns)
 
-	return nil
+	return &Conn{db: reader, writer: writer, dialect: dialect, maxIdle: opts.MaxIdleConns}, nil
 }
 
-func GetDB() *sql.DB {
-	return db
+// open opens and pings a database
+func open(dialect Dialect, dataSourceName string) (*sql.DB, error) {
+	conn, err := sql.Open(dialect.Driver(), dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// withParam adds a connection parameter to a SQLite data source name,
+// unless the name already sets it
+func withParam(dataSourceName, name, value string) string {
+	if strings.Contains(dataSourceName, name+"=") {
+		return dataSourceName
+	}
+	if strings.Contains(dataSourceName, "?") {
+		return dataSourceName + "&" + name + "=" + value
+	}
+	return dataSourceName + "?" + name + "=" + value
 }
 
-func CloseDB() error {
-	if db != nil {
-		return db.Close()
+// IsUniqueViolation reports whether err is a statement failing a UNIQUE
+// constraint, in either database
+func IsUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
 	}
-	return nil
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// FilePath returns the file holding a SQLite database, or false for
+// PostgreSQL and in-memory databases
+func FilePath(dataSourceName string) (string, bool) {
+	if DialectFor(dataSourceName) != SQLite {
+		return "", false
+	}
+	path, query, _ := strings.Cut(strings.TrimPrefix(dataSourceName, "file:"), "?")
+	if path == "" || path == ":memory:" || strings.Contains(query, "mode=memory") {
+		return "", false
+	}
+	return path, true
+}
+
+// Checkpoint copies the write-ahead log into the database file and truncates
+// it, so nothing is left in the WAL when the server exits
+func Checkpoint(conn *sql.DB) error {
+	_, err := conn.Exec("PRAGMA wal_checkpoint(TRUNCATE)")
+	return err
 }
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConnect(t *testing.T) {
+	// Setup
+	conn, err := Connect(filepath.Join(t.TempDir(), "test.db"), Options{
+		JournalMode:  "WAL",
+		Synchronous:  "NORMAL",
+		BusyTimeout:  2 * time.Second,
+		MaxOpenConns: 4,
+		MaxIdleConns: 4,
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+
+	// Test the pragmas apply to reads and writes
+	pragmas := map[string]string{
+		"journal_mode": "wal",
+		"synchronous":  "1",
+		"busy_timeout": "2000",
+		"foreign_keys": "1",
+	}
+	for pragma, want := range pragmas {
+		var got string
+		if err := conn.QueryRowContext(ctx, "PRAGMA "+pragma).Scan(&got); err != nil {
+			t.Fatalf("Failed to read %s: %v", pragma, err)
+		}
+		if got != want {
+			t.Errorf("Expected %s %s, got %s", pragma, want, got)
+		}
+	}
+
+	// Test writes go through a single connection
+	if stats := conn.DB().Stats(); stats.MaxOpenConnections != 1 {
+		t.Errorf("Expected a single writer connection, got %d", stats.MaxOpenConnections)
+	}
+}
+
+func TestFilePath(t *testing.T) {
+	tests := []struct {
+		dsn    string
+		want   string
+		isFile bool
+	}{
+		{"words.db", "words.db", true},
+		{"file:/data/words.db?_journal_mode=WAL", "/data/words.db", true},
+		{":memory:", "", false},
+		{"file:test?mode=memory&cache=shared", "", false},
+		{"postgres://app@localhost/words", "", false},
+	}
+
+	for _, tt := range tests {
+		if got, ok := FilePath(tt.dsn); got != tt.want || ok != tt.isFile {
+			t.Errorf("FilePath(%q) = %q, %v, want %q, %v", tt.dsn, got, ok, tt.want, tt.isFile)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// Dialect describes the SQL differences between the supported databases.
+// Queries are written with ? placeholders and SQL that both databases
+// accept; the dialect fills in the rest.
+type Dialect interface {
+	// Driver is the database/sql driver name
+	Driver() string
+	// Rebind rewrites ? placeholders into the database's bind syntax
+	Rebind(query string) string
+	// Timestamp wraps a timestamp expression so it compares and sorts like a
+	// UTC "2006-01-02 15:04:05" string
+	Timestamp(expr string) string
+	// MinTimestamp is a literal that sorts before every Timestamp value
+	MinTimestamp() string
+	// Returning reports whether inserted IDs are read with RETURNING id
+	// rather than sql.Result.LastInsertId
+	Returning() bool
+	// ResetSequence returns a statement restarting a table's IDs at 1
+	ResetSequence(table string) (string, []interface{})
+	// HasTable returns a query reporting whether a table exists
+	HasTable(table string) (string, []interface{})
+	// Migrations returns the part of a migrations tree, such as
+	// migrations.FS, holding this dialect's migrations
+	Migrations(fsys fs.FS) fs.FS
+}
+
+var (
+	// SQLite is the dialect of SQLite databases
+	SQLite Dialect = sqlite{}
+	// Postgres is the dialect of PostgreSQL databases
+	Postgres Dialect = postgres{}
+)
+
+// DialectFor returns Postgres for postgres:// and postgresql:// data source
+// names, and SQLite for anything else
+func DialectFor(dataSourceName string) Dialect {
+	if strings.HasPrefix(dataSourceName, "postgres://") || strings.HasPrefix(dataSourceName, "postgresql://") {
+		return Postgres
+	}
+	return SQLite
+}
+
+type sqlite struct{}
+
+func (sqlite) Driver() string {
+	return "sqlite3"
+}
+
+func (sqlite) Rebind(query string) string {
+	return query
+}
+
+func (sqlite) Timestamp(expr string) string {
+	return "datetime(" + expr + ")"
+}
+
+func (sqlite) MinTimestamp() string {
+	return "''"
+}
+
+func (sqlite) Returning() bool {
+	return false
+}
+
+func (sqlite) ResetSequence(table string) (string, []interface{}) {
+	return "DELETE FROM sqlite_sequence WHERE name = ?", []interface{}{table}
+}
+
+func (sqlite) HasTable(table string) (string, []interface{}) {
+	return "SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = ?)", []interface{}{table}
+}
+
+func (sqlite) Migrations(fsys fs.FS) fs.FS {
+	return fsys
+}
+
+type postgres struct{}
+
+func (postgres) Driver() string {
+	return "postgres"
+}
+
+// Rebind numbers the placeholders $1, $2, ..., leaving question marks in
+// string literals alone
+func (postgres) Rebind(query string) string {
+	var b strings.Builder
+	n := 0
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == '?' && !quoted:
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (postgres) Timestamp(expr string) string {
+	return "(" + expr + " AT TIME ZONE 'UTC')"
+}
+
+func (postgres) MinTimestamp() string {
+	return "TIMESTAMP '0001-01-01 00:00:00'"
+}
+
+func (postgres) Returning() bool {
+	return true
+}
+
+func (postgres) ResetSequence(table string) (string, []interface{}) {
+	return "SELECT setval(pg_get_serial_sequence(?, 'id'), 1, false)", []interface{}{table}
+}
+
+func (postgres) HasTable(table string) (string, []interface{}) {
+	return "SELECT to_regclass(?) IS NOT NULL", []interface{}{table}
+}
+
+func (postgres) Migrations(fsys fs.FS) fs.FS {
+	// fs.Sub only fails for invalid paths, which "postgres" is not
+	sub, _ := fs.Sub(fsys, "postgres")
+	return sub
+}
@@ -0,0 +1,34 @@
+package db
+
+import "testing"
+
+func TestDialectFor(t *testing.T) {
+	tests := []struct {
+		dsn  string
+		want Dialect
+	}{
+		{"words.db", SQLite},
+		{"file:words.db?_journal_mode=WAL", SQLite},
+		{"postgres://app@localhost/words", Postgres},
+		{"postgresql://app@localhost/words", Postgres},
+	}
+
+	for _, tt := range tests {
+		if got := DialectFor(tt.dsn); got != tt.want {
+			t.Errorf("DialectFor(%q) = %T, want %T", tt.dsn, got, tt.want)
+		}
+	}
+}
+
+func TestRebind(t *testing.T) {
+	query := "SELECT * FROM words WHERE id = ? AND english <> '?' AND level = ?"
+
+	if got := SQLite.Rebind(query); got != query {
+		t.Errorf("Expected SQLite to leave the query alone, got %q", got)
+	}
+
+	want := "SELECT * FROM words WHERE id = $1 AND english <> '?' AND level = $2"
+	if got := Postgres.Rebind(query); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
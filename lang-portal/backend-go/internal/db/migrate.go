@@ -0,0 +1,437 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Migrate brings a database up to date with the migrations in fsys, as
+// selected by dialect.Migrations. A database without tables is created from
+// scratch and filled with the seed script, which may be empty. It reports
+// whether the database was new.
+//
+// Databases from before schema_migrations existed get every migration
+// applied, so migrations must be safe to run against tables they created.
+func Migrate(conn *sql.DB, dialect Dialect, fsys fs.FS, seed string) (bool, error) {
+	query, args := dialect.HasTable("words")
+	var exists bool
+	if err := conn.QueryRow(dialect.Rebind(query), args...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to inspect database: %v", err)
+	}
+
+	if err := RunMigrations(conn, dialect, fsys); err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+	if err := ExecScript(conn, seed); err != nil {
+		return true, fmt.Errorf("failed to seed database: %v", err)
+	}
+	return true, nil
+}
+
+// RunMigrations applies the *.up.sql files in fsys, as selected by
+// dialect.Migrations, that have not been applied yet, in file name order,
+// recording each in schema_migrations. A file's version is the number its
+// name starts with, e.g. 5 for 005_add_tags.up.sql.
+func RunMigrations(conn *sql.DB, dialect Dialect, fsys fs.FS) error {
+	fsys = dialect.Migrations(fsys)
+	files, err := fs.Glob(fsys, "*.up.sql")
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %v", err)
+	}
+	sort.Strings(files)
+
+	if _, err := conn.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)
+	`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %v", err)
+	}
+	current, err := appliedVersion(context.Background(), conn)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		version, err := migrationVersion(file)
+		if err != nil {
+			return err
+		}
+		if version <= current {
+			continue
+		}
+		script, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		record := "INSERT INTO schema_migrations (version) VALUES (" + strconv.Itoa(version) + ")"
+		if err := applyMigration(conn, dialect, string(script), record); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %v", file, err)
+		}
+	}
+	return nil
+}
+
+// Rollback reverts the latest applied migration with its *.down.sql file in
+// fsys, as selected by dialect.Migrations, and returns its version, or 0 if
+// no migration has been applied
+func Rollback(conn *sql.DB, dialect Dialect, fsys fs.FS) (int, error) {
+	migrations, err := MigrationStatus(conn, dialect, fsys)
+	if err != nil {
+		return 0, err
+	}
+	var latest *Migration
+	for i := range migrations {
+		if migrations[i].AppliedAt != nil {
+			latest = &migrations[i]
+		}
+	}
+	if latest == nil {
+		return 0, nil
+	}
+
+	file := strings.TrimSuffix(latest.Name, ".up.sql") + ".down.sql"
+	script, err := fs.ReadFile(dialect.Migrations(fsys), file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migration %s: %v", file, err)
+	}
+	record := "DELETE FROM schema_migrations WHERE version = " + strconv.Itoa(latest.Version)
+	if err := applyMigration(conn, dialect, string(script), record); err != nil {
+		return 0, fmt.Errorf("failed to roll back migration %s: %v", file, err)
+	}
+	return latest.Version, nil
+}
+
+// Migration is a migration file and when it was applied, if it has been
+type Migration struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// MigrationStatus lists the *.up.sql files in fsys, as selected by
+// dialect.Migrations, in version order along with when each was applied
+func MigrationStatus(conn *sql.DB, dialect Dialect, fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(dialect.Migrations(fsys), "*.up.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %v", err)
+	}
+	sort.Strings(files)
+
+	query, args := dialect.HasTable("schema_migrations")
+	var exists bool
+	if err := conn.QueryRow(dialect.Rebind(query), args...).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %v", err)
+	}
+	applied := make(map[int]time.Time)
+	if exists {
+		rows, err := conn.Query("SELECT version, applied_at FROM schema_migrations")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read schema_migrations: %v", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var version int
+			var appliedAt time.Time
+			if err := rows.Scan(&version, &appliedAt); err != nil {
+				return nil, fmt.Errorf("failed to read schema_migrations: %v", err)
+			}
+			applied[version] = appliedAt
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	migrations := make([]Migration, 0, len(files))
+	for _, file := range files {
+		version, err := migrationVersion(file)
+		if err != nil {
+			return nil, err
+		}
+		migration := Migration{Version: version, Name: file}
+		if appliedAt, ok := applied[version]; ok {
+			migration.AppliedAt = &appliedAt
+		}
+		migrations = append(migrations, migration)
+	}
+	return migrations, nil
+}
+
+// SchemaVersion returns the version of the latest migration applied to the
+// database, or 0 if none has been recorded
+func SchemaVersion(ctx context.Context, conn *sql.DB, dialect Dialect) (int, error) {
+	query, args := dialect.HasTable("schema_migrations")
+	var exists bool
+	if err := conn.QueryRowContext(ctx, dialect.Rebind(query), args...).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if !exists {
+		return 0, nil
+	}
+	return appliedVersion(ctx, conn)
+}
+
+// LatestVersion returns the version of the last *.up.sql file in fsys, the
+// version a fully migrated database is at, or 0 if there are none
+func LatestVersion(fsys fs.FS) (int, error) {
+	files, err := fs.Glob(fsys, "*.up.sql")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list migrations: %v", err)
+	}
+	latest := 0
+	for _, file := range files {
+		version, err := migrationVersion(file)
+		if err != nil {
+			return 0, err
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
+
+// appliedVersion returns the latest version recorded in schema_migrations
+func appliedVersion(ctx context.Context, conn *sql.DB) (int, error) {
+	var version int
+	if err := conn.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// migrationVersion parses the version number a migration file name starts with
+func migrationVersion(file string) (int, error) {
+	prefix, _, _ := strings.Cut(path.Base(file), "_")
+	version, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0, fmt.Errorf("migration %s does not start with a version number", path.Base(file))
+	}
+	return version, nil
+}
+
+// ExecScript executes each statement of a SQL script. The statements share
+// one connection, so connection settings such as PRAGMA foreign_keys carry
+// over. If a statement fails the connection is discarded rather than
+// returned to the pool with those settings or an open transaction.
+func ExecScript(conn *sql.DB, script string) error {
+	ctx := context.Background()
+	c, err := conn.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err := execStatements(ctx, c, script); err != nil {
+		c.Raw(func(interface{}) error { return driver.ErrBadConn })
+		return err
+	}
+	return nil
+}
+
+// applyMigration executes a migration script and the statement recording it
+// in schema_migrations in one transaction, so a failing migration leaves
+// neither its changes nor its record behind. SQLite migrations run with
+// foreign keys switched off, as rebuilding a table requires. The pragma has
+// no effect inside a transaction, so it is set on the connection around it.
+func applyMigration(conn *sql.DB, dialect Dialect, script, record string) (err error) {
+	ctx := context.Background()
+	c, err := conn.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	defer func() {
+		if err != nil {
+			c.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}()
+
+	var enforced bool
+	if dialect == SQLite {
+		if err := c.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&enforced); err != nil {
+			return err
+		}
+		if _, err := c.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+			return err
+		}
+	}
+
+	tx, err := c.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := execStatements(ctx, tx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record); err != nil {
+		return fmt.Errorf("failed to record migration: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if enforced {
+		_, err = c.ExecContext(ctx, "PRAGMA foreign_keys = ON")
+	}
+	return err
+}
+
+// execStatements executes each statement of a SQL script. DELETE statements
+// that remove rows, such as the repairs made before enforcing foreign keys,
+// are logged with the number of rows removed.
+func execStatements(ctx context.Context, c execer, script string) error {
+	for _, stmt := range Statements(script) {
+		result, err := c.ExecContext(ctx, stmt)
+		if err != nil {
+			return err
+		}
+		if table, ok := deleteTable(stmt); ok {
+			if removed, err := result.RowsAffected(); err == nil && removed > 0 {
+				slog.Warn("Removed rows", "table", table, "rows", removed)
+			}
+		}
+	}
+	return nil
+}
+
+// deleteTable returns the table a DELETE statement removes rows from
+func deleteTable(stmt string) (string, bool) {
+	fields := strings.Fields(stripComments(stmt))
+	if len(fields) < 3 || !strings.EqualFold(fields[0], "DELETE") || !strings.EqualFold(fields[1], "FROM") {
+		return "", false
+	}
+	return fields[2], true
+}
+
+// stripComments removes -- comments from a statement
+func stripComments(stmt string) string {
+	lines := strings.Split(stmt, "\n")
+	for i, line := range lines {
+		if before, _, found := strings.Cut(line, "--"); found {
+			lines[i] = before
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Statements splits a SQL script into its individual statements at the
+// semicolons ending them. Semicolons in quoted strings and identifiers,
+// comments, PostgreSQL dollar-quoted strings and the BEGIN ... END body of
+// a CREATE TRIGGER statement do not end a statement.
+func Statements(script string) []string {
+	var statements []string
+	start := 0
+	// words counts the words of the current statement and depth the BEGIN
+	// and CASE blocks open in a trigger body
+	words, depth := 0, 0
+	create, trigger := false, false
+	for i := 0; i < len(script); {
+		c := script[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(script, i)
+		case strings.HasPrefix(script[i:], "--"):
+			if end := strings.IndexByte(script[i:], '\n'); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(script)
+			}
+		case strings.HasPrefix(script[i:], "/*"):
+			if end := strings.Index(script[i+2:], "*/"); end >= 0 {
+				i += end + 4
+			} else {
+				i = len(script)
+			}
+		case c == '$':
+			i = skipDollarQuoted(script, i)
+		case isWordStart(c):
+			end := i + 1
+			for end < len(script) && (isWordStart(script[end]) || isDigit(script[end]) || script[end] == '$') {
+				end++
+			}
+			word := strings.ToUpper(script[i:end])
+			switch {
+			case words == 0:
+				create = word == "CREATE"
+			case create && words <= 2 && word == "TRIGGER":
+				trigger = true
+			case trigger && (word == "BEGIN" || word == "CASE"):
+				depth++
+			case trigger && word == "END" && depth > 0:
+				depth--
+			}
+			words++
+			i = end
+		case c == ';' && depth == 0:
+			if stmt := strings.TrimSpace(script[start:i]); stmt != "" {
+				statements = append(statements, stmt)
+			}
+			start = i + 1
+			words, create, trigger = 0, false, false
+			i++
+		default:
+			i++
+		}
+	}
+	if stmt := strings.TrimSpace(script[start:]); stmt != "" {
+		statements = append(statements, stmt)
+	}
+	return statements
+}
+
+// skipQuoted returns the index after the string or identifier opened by the
+// quote at i, where a doubled quote stands for itself
+func skipQuoted(script string, i int) int {
+	quote := script[i]
+	for i++; i < len(script); i++ {
+		if script[i] != quote {
+			continue
+		}
+		if i+1 < len(script) && script[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(script)
+}
+
+// skipDollarQuoted returns the index after the PostgreSQL dollar-quoted
+// string, such as $$...$$ or $body$...$body$, starting at i, or i+1 if the
+// dollar sign does not open one, as in a $1 placeholder
+func skipDollarQuoted(script string, i int) int {
+	end := i + 1
+	for end < len(script) && (isWordStart(script[end]) || (end > i+1 && isDigit(script[end]))) {
+		end++
+	}
+	if end >= len(script) || script[end] != '$' {
+		return i + 1
+	}
+	tag := script[i : end+1]
+	if close := strings.Index(script[end+1:], tag); close >= 0 {
+		return end + 1 + close + len(tag)
+	}
+	return len(script)
+}
+
+func isWordStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,407 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db/migrations"
+)
+
+// execMigration applies one of the embedded SQLite migration files
+func execMigration(t *testing.T, conn *sql.DB, file string) {
+	t.Helper()
+	script, err := fs.ReadFile(migrations.FS, file)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", file, err)
+	}
+	if err := ExecScript(conn, string(script)); err != nil {
+		t.Fatalf("Failed to apply %s: %v", file, err)
+	}
+}
+
+func TestForeignKeyMigration(t *testing.T) {
+	// Setup a database created before foreign keys were enforced
+	path := filepath.Join(t.TempDir(), "test.db")
+	legacy, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer legacy.Close()
+	for _, file := range []string{"001_create_tables", "002_create_word_audio", "003_create_images", "004_create_tags"} {
+		execMigration(t, legacy, file+".up.sql")
+	}
+	_, err = legacy.Exec(`
+		INSERT INTO words (id, english, spanish, level) VALUES (1, 'hello', 'hola', 'beginner');
+		INSERT INTO groups (id, name) VALUES (1, 'Greetings');
+		INSERT INTO study_activities (id, name) VALUES (1, 'Flashcards');
+		INSERT INTO word_groups (word_id, group_id) VALUES (1, 1), (1, 1), (2, 1), (1, 2);
+		INSERT INTO study_activity_groups (study_activity_id, group_id) VALUES (1, 1), (1, 1);
+		INSERT INTO study_sessions (study_activity_id, group_id) VALUES (1, 1), (1, 2);
+		INSERT INTO word_review_items (word_id, study_activity_id, correct) VALUES (1, 1, TRUE), (2, 1, TRUE);
+	`)
+	if err != nil {
+		t.Fatalf("Failed to insert legacy data: %v", err)
+	}
+
+	// Test the migration repairs orphans and duplicates, logging what it removes
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	err = RunMigrations(legacy, SQLite, migrations.FS)
+	slog.SetDefault(previous)
+	if err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+	for _, want := range []string{"table=word_groups rows=2", "table=word_groups rows=1", "table=study_sessions rows=1"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("Expected the repair to log %q, got %q", want, logs.String())
+		}
+	}
+	counts := map[string]int{
+		"word_groups":           1,
+		"study_activity_groups": 1,
+		"study_sessions":        1,
+		"word_review_items":     1,
+	}
+	for table, want := range counts {
+		var got int
+		if err := legacy.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&got); err != nil {
+			t.Fatalf("Failed to count %s: %v", table, err)
+		}
+		if got != want {
+			t.Errorf("Expected %d rows in %s, got %d", want, table, got)
+		}
+	}
+
+	// Test connections enforce foreign keys and their ON DELETE actions
+	conn, err := Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Exec("INSERT INTO word_groups (word_id, group_id) VALUES (1, 1)"); err == nil {
+		t.Error("Expected duplicate word group to be rejected")
+	}
+	if _, err := conn.Exec("INSERT INTO word_groups (word_id, group_id) VALUES (99, 1)"); err == nil {
+		t.Error("Expected word group of a missing word to be rejected")
+	}
+	if _, err := conn.Exec("DELETE FROM groups WHERE id = 1"); err == nil {
+		t.Error("Expected deleting a group with study sessions to be rejected")
+	}
+	if _, err := conn.Exec("DELETE FROM words WHERE id = 1"); err != nil {
+		t.Fatalf("Failed to delete word: %v", err)
+	}
+	var links int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM word_groups").Scan(&links); err != nil {
+		t.Fatalf("Failed to count word groups: %v", err)
+	}
+	if links != 0 {
+		t.Errorf("Expected word groups to be deleted with the word, got %d", links)
+	}
+}
+
+func TestStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "statements",
+			script: "CREATE TABLE t (id INTEGER);\n\nINSERT INTO t VALUES (1);\n",
+			want:   []string{"CREATE TABLE t (id INTEGER)", "INSERT INTO t VALUES (1)"},
+		},
+		{
+			name:   "quoted semicolons",
+			script: `INSERT INTO t (a, "b;c") VALUES ('x; y', 'it''s; fine'); SELECT 1`,
+			want:   []string{`INSERT INTO t (a, "b;c") VALUES ('x; y', 'it''s; fine')`, "SELECT 1"},
+		},
+		{
+			name:   "comments",
+			script: "-- first; comment\nSELECT 1; /* block; comment */ SELECT 2;\n-- trailing;",
+			want:   []string{"-- first; comment\nSELECT 1", "/* block; comment */ SELECT 2", "-- trailing;"},
+		},
+		{
+			name: "trigger",
+			script: `CREATE TEMP TRIGGER log AFTER INSERT ON t BEGIN
+				INSERT INTO l VALUES (CASE WHEN NEW.id > 0 THEN 'up; ' ELSE 'down' END);
+				UPDATE c SET n = n + 1;
+			END; SELECT 1`,
+			want: []string{`CREATE TEMP TRIGGER log AFTER INSERT ON t BEGIN
+				INSERT INTO l VALUES (CASE WHEN NEW.id > 0 THEN 'up; ' ELSE 'down' END);
+				UPDATE c SET n = n + 1;
+			END`, "SELECT 1"},
+		},
+		{
+			name:   "transaction",
+			script: "BEGIN; DELETE FROM t; END;",
+			want:   []string{"BEGIN", "DELETE FROM t", "END"},
+		},
+		{
+			name:   "dollar quotes",
+			script: "CREATE FUNCTION f() RETURNS trigger AS $body$ BEGIN RETURN NEW; END; $body$ LANGUAGE plpgsql; SELECT $1",
+			want:   []string{"CREATE FUNCTION f() RETURNS trigger AS $body$ BEGIN RETURN NEW; END; $body$ LANGUAGE plpgsql", "SELECT $1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Statements(tt.script); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected statements %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExecScriptTrigger(t *testing.T) {
+	// Setup
+	conn, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+
+	// Test a trigger body and a string with semicolons run as written
+	err = ExecScript(conn, `
+		CREATE TABLE notes (body TEXT);
+		CREATE TABLE counts (n INTEGER);
+		INSERT INTO counts VALUES (0);
+		CREATE TRIGGER count_notes AFTER INSERT ON notes BEGIN
+			UPDATE counts SET n = n + 1;
+		END;
+		INSERT INTO notes VALUES ('one; two');
+	`)
+	if err != nil {
+		t.Fatalf("Failed to execute script: %v", err)
+	}
+	var body string
+	var n int
+	if err := conn.QueryRow("SELECT body, (SELECT n FROM counts) FROM notes").Scan(&body, &n); err != nil {
+		t.Fatalf("Failed to read note: %v", err)
+	}
+	if body != "one; two" || n != 1 {
+		t.Errorf("Expected note %q counted once, got %q counted %d times", "one; two", body, n)
+	}
+}
+
+func TestExecScriptFailure(t *testing.T) {
+	// Setup a pool of one connection enforcing foreign keys
+	conn, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	// Test a failing script leaves no transaction or settings behind
+	err = ExecScript(conn, "PRAGMA foreign_keys = OFF; BEGIN; CREATE TABLE t (id INTEGER); INSERT INTO missing VALUES (1);")
+	if err == nil {
+		t.Fatal("Expected the script to fail")
+	}
+	var enabled int
+	if err := conn.QueryRow("PRAGMA foreign_keys").Scan(&enabled); err != nil || enabled != 1 {
+		t.Errorf("Expected foreign keys to be enforced, got %d %v", enabled, err)
+	}
+	if _, err := conn.Exec("BEGIN; ROLLBACK"); err != nil {
+		t.Errorf("Expected no transaction to be open, got %v", err)
+	}
+}
+
+func TestRunMigrationsAtomic(t *testing.T) {
+	// Setup a pool of one connection enforcing foreign keys
+	conn, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+	fsys := fstest.MapFS{
+		"001_create_parents.up.sql":   {Data: []byte("CREATE TABLE parents (id INTEGER PRIMARY KEY);")},
+		"001_create_parents.down.sql": {Data: []byte("DROP TABLE parents; DROP TABLE missing;")},
+		"002_create_children.up.sql": {Data: []byte(`
+			CREATE TABLE children (id INTEGER PRIMARY KEY, parent_id INTEGER REFERENCES parents(id));
+			INSERT INTO missing VALUES (1);
+		`)},
+	}
+
+	// Test a failing migration leaves neither its changes nor its record
+	if err := RunMigrations(conn, SQLite, fsys); err == nil {
+		t.Fatal("Expected the migration to fail")
+	}
+	version, err := SchemaVersion(context.Background(), conn, SQLite)
+	if err != nil || version != 1 {
+		t.Errorf("Expected version 1, got %d (%v)", version, err)
+	}
+	var exists bool
+	if err := conn.QueryRow("SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE name = 'children')").Scan(&exists); err != nil || exists {
+		t.Errorf("Expected the failed migration's table to be rolled back, got %v (%v)", exists, err)
+	}
+	var enabled int
+	if err := conn.QueryRow("PRAGMA foreign_keys").Scan(&enabled); err != nil || enabled != 1 {
+		t.Errorf("Expected foreign keys to be enforced, got %d %v", enabled, err)
+	}
+
+	// Test a failing rollback keeps the migration and its record
+	if _, err := Rollback(conn, SQLite, fsys); err == nil {
+		t.Fatal("Expected the rollback to fail")
+	}
+	version, err = SchemaVersion(context.Background(), conn, SQLite)
+	if err != nil || version != 1 {
+		t.Errorf("Expected version 1 after the failed rollback, got %d (%v)", version, err)
+	}
+	if _, err := conn.Exec("INSERT INTO parents (id) VALUES (1)"); err != nil {
+		t.Errorf("Expected the table to survive the failed rollback, got %v", err)
+	}
+}
+
+func TestRunMigrationsVersions(t *testing.T) {
+	// Setup
+	conn, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+
+	// Test an empty database has no version
+	version, err := SchemaVersion(context.Background(), conn, SQLite)
+	if err != nil || version != 0 {
+		t.Fatalf("Expected version 0, got %d (%v)", version, err)
+	}
+
+	// Test applied migrations are recorded and skipped on later runs
+	for i := 0; i < 2; i++ {
+		if err := RunMigrations(conn, SQLite, migrations.FS); err != nil {
+			t.Fatalf("Failed to run migrations (run %d): %v", i+1, err)
+		}
+	}
+	version, err = SchemaVersion(context.Background(), conn, SQLite)
+	if err != nil || version != 5 {
+		t.Errorf("Expected version 5, got %d (%v)", version, err)
+	}
+
+	// Test the applied version is the latest migration's
+	latest, err := LatestVersion(SQLite.Migrations(migrations.FS))
+	if err != nil || latest != version {
+		t.Errorf("Expected latest version %d, got %d (%v)", version, latest, err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	// Setup
+	conn, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+	seed := "INSERT INTO groups (name) VALUES ('Seeded')"
+
+	// Test an empty database is created and seeded once
+	for i, wantCreated := range []bool{true, false} {
+		created, err := Migrate(conn, SQLite, migrations.FS, seed)
+		if err != nil {
+			t.Fatalf("Failed to migrate (run %d): %v", i+1, err)
+		}
+		if created != wantCreated {
+			t.Errorf("Expected created %v on run %d, got %v", wantCreated, i+1, created)
+		}
+	}
+	var groups int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM groups").Scan(&groups); err != nil {
+		t.Fatalf("Failed to count groups: %v", err)
+	}
+	if groups != 1 {
+		t.Errorf("Expected 1 seeded group, got %d", groups)
+	}
+	version, err := SchemaVersion(context.Background(), conn, SQLite)
+	if err != nil || version != 5 {
+		t.Errorf("Expected version 5, got %d (%v)", version, err)
+	}
+}
+
+func TestMigrateLegacy(t *testing.T) {
+	// Setup a database created before schema_migrations existed
+	conn, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+	execMigration(t, conn, "001_create_tables.up.sql")
+	execMigration(t, conn, "002_create_word_audio.up.sql")
+	execMigration(t, conn, "003_create_images.up.sql")
+	if _, err := conn.Exec("INSERT INTO words (english, spanish, level) VALUES ('hello', 'hola', 'beginner')"); err != nil {
+		t.Fatalf("Failed to insert word: %v", err)
+	}
+
+	// Test every migration is applied again without losing data
+	created, err := Migrate(conn, SQLite, migrations.FS, "")
+	if err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if created {
+		t.Error("Expected an existing database not to be reported as created")
+	}
+	version, err := SchemaVersion(context.Background(), conn, SQLite)
+	if err != nil || version != 5 {
+		t.Errorf("Expected version 5, got %d (%v)", version, err)
+	}
+	var words int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM words").Scan(&words); err != nil {
+		t.Fatalf("Failed to count words: %v", err)
+	}
+	if words != 1 {
+		t.Errorf("Expected the existing word to be kept, got %d words", words)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	// Setup
+	conn, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+	if _, err := Migrate(conn, SQLite, migrations.FS, ""); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	// Test the latest migration is rolled back and reported pending
+	version, err := Rollback(conn, SQLite, migrations.FS)
+	if err != nil || version != 5 {
+		t.Fatalf("Expected to roll back version 5, got %d (%v)", version, err)
+	}
+	status, err := MigrationStatus(conn, SQLite, migrations.FS)
+	if err != nil {
+		t.Fatalf("Failed to read migration status: %v", err)
+	}
+	if len(status) != 5 || status[3].AppliedAt == nil || status[4].AppliedAt != nil {
+		t.Errorf("Expected migrations 1-4 applied and 5 pending, got %+v", status)
+	}
+	if status[4].Name != "005_enforce_foreign_keys.up.sql" {
+		t.Errorf("Expected migration 5 to be named by its file, got %q", status[4].Name)
+	}
+
+	// Test rolling back every migration leaves nothing to roll back
+	for want := 4; want >= 1; want-- {
+		if version, err := Rollback(conn, SQLite, migrations.FS); err != nil || version != want {
+			t.Fatalf("Expected to roll back version %d, got %d (%v)", want, version, err)
+		}
+	}
+	if version, err := Rollback(conn, SQLite, migrations.FS); err != nil || version != 0 {
+		t.Errorf("Expected nothing to roll back, got %d (%v)", version, err)
+	}
+	var tables int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'words'").Scan(&tables); err != nil {
+		t.Fatalf("Failed to look for words table: %v", err)
+	}
+	if tables != 0 {
+		t.Error("Expected the words table to be dropped")
+	}
+}
@@ -66,10 +66,10 @@ CREATE TABLE IF NOT EXISTS word_review_items (
 );
 
 -- Create indexes
-CREATE INDEX idx_words_level ON words(level);
-CREATE INDEX idx_word_groups_word_id ON word_groups(word_id);
-CREATE INDEX idx_word_groups_group_id ON word_groups(group_id);
-CREATE INDEX idx_study_sessions_group_id ON study_sessions(group_id);
-CREATE INDEX idx_study_sessions_activity_id ON study_sessions(study_activity_id);
-CREATE INDEX idx_word_review_items_word_id ON word_review_items(word_id);
-CREATE INDEX idx_word_review_items_activity_id ON word_review_items(study_activity_id);
+CREATE INDEX IF NOT EXISTS idx_words_level ON words(level);
+CREATE INDEX IF NOT EXISTS idx_word_groups_word_id ON word_groups(word_id);
+CREATE INDEX IF NOT EXISTS idx_word_groups_group_id ON word_groups(group_id);
+CREATE INDEX IF NOT EXISTS idx_study_sessions_group_id ON study_sessions(group_id);
+CREATE INDEX IF NOT EXISTS idx_study_sessions_activity_id ON study_sessions(study_activity_id);
+CREATE INDEX IF NOT EXISTS idx_word_review_items_word_id ON word_review_items(word_id);
+CREATE INDEX IF NOT EXISTS idx_word_review_items_activity_id ON word_review_items(study_activity_id);
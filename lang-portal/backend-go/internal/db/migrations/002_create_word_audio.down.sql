@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_word_audio_word_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS word_audio;
@@ -0,0 +1,13 @@
+-- Create word_audio table (pronunciation clips stored in the media directory)
+CREATE TABLE IF NOT EXISTS word_audio (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (word_id) REFERENCES words(id)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_word_audio_word_id ON word_audio(word_id);
@@ -0,0 +1,7 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_word_images_image_id;
+DROP INDEX IF EXISTS idx_word_images_word_image;
+
+-- Drop tables
+DROP TABLE IF EXISTS word_images;
+DROP TABLE IF EXISTS images;
@@ -0,0 +1,7 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_word_tags_tag_id;
+DROP INDEX IF EXISTS idx_word_tags_word_tag;
+
+-- Drop tables
+DROP TABLE IF EXISTS word_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,21 @@
+-- Create tags table
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create word_tags table (many-to-many join table)
+CREATE TABLE IF NOT EXISTS word_tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (word_id) REFERENCES words(id),
+    FOREIGN KEY (tag_id) REFERENCES tags(id)
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_word_tags_word_tag ON word_tags(word_id, tag_id);
+CREATE INDEX IF NOT EXISTS idx_word_tags_tag_id ON word_tags(tag_id);
@@ -0,0 +1,5 @@
+-- Drop indexes. The rebuilt tables keep their ON DELETE actions, which the
+-- earlier schema's manual deletes are compatible with.
+DROP INDEX IF EXISTS idx_study_activity_groups_group_id;
+DROP INDEX IF EXISTS idx_study_activity_groups_activity_group;
+DROP INDEX IF EXISTS idx_word_groups_word_group;
@@ -0,0 +1,139 @@
+-- Enforce foreign keys with explicit ON DELETE actions and unique join rows.
+-- SQLite cannot alter constraints, so each referencing table is rebuilt with
+-- foreign keys switched off, as SQLite migrations run, after repairing rows
+-- that would violate them.
+
+-- Remove rows referencing missing words, groups, activities, images or tags
+DELETE FROM word_groups
+WHERE word_id NOT IN (SELECT id FROM words) OR group_id NOT IN (SELECT id FROM groups);
+DELETE FROM study_activity_groups
+WHERE study_activity_id NOT IN (SELECT id FROM study_activities) OR group_id NOT IN (SELECT id FROM groups);
+DELETE FROM study_sessions
+WHERE study_activity_id NOT IN (SELECT id FROM study_activities) OR group_id NOT IN (SELECT id FROM groups);
+DELETE FROM word_review_items
+WHERE word_id NOT IN (SELECT id FROM words) OR study_activity_id NOT IN (SELECT id FROM study_activities);
+DELETE FROM word_audio
+WHERE word_id NOT IN (SELECT id FROM words);
+DELETE FROM word_images
+WHERE word_id NOT IN (SELECT id FROM words) OR image_id NOT IN (SELECT id FROM images);
+DELETE FROM word_tags
+WHERE word_id NOT IN (SELECT id FROM words) OR tag_id NOT IN (SELECT id FROM tags);
+
+-- Remove duplicate links, keeping the oldest
+DELETE FROM word_groups
+WHERE id NOT IN (SELECT MIN(id) FROM word_groups GROUP BY word_id, group_id);
+DELETE FROM study_activity_groups
+WHERE id NOT IN (SELECT MIN(id) FROM study_activity_groups GROUP BY study_activity_id, group_id);
+
+-- Links are removed with either side
+CREATE TABLE word_groups_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL,
+    group_id INTEGER NOT NULL,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
+);
+INSERT INTO word_groups_new (id, word_id, group_id)
+SELECT id, word_id, group_id FROM word_groups;
+DROP TABLE word_groups;
+ALTER TABLE word_groups_new RENAME TO word_groups;
+
+CREATE TABLE study_activity_groups_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    study_activity_id INTEGER NOT NULL,
+    group_id INTEGER NOT NULL,
+    FOREIGN KEY (study_activity_id) REFERENCES study_activities(id) ON DELETE CASCADE,
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
+);
+INSERT INTO study_activity_groups_new (id, study_activity_id, group_id)
+SELECT id, study_activity_id, group_id FROM study_activity_groups;
+DROP TABLE study_activity_groups;
+ALTER TABLE study_activity_groups_new RENAME TO study_activity_groups;
+
+-- Study history keeps its group and activity until the history is reset
+CREATE TABLE study_sessions_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    group_id INTEGER NOT NULL,
+    study_activity_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE RESTRICT,
+    FOREIGN KEY (study_activity_id) REFERENCES study_activities(id) ON DELETE RESTRICT
+);
+INSERT INTO study_sessions_new (id, group_id, study_activity_id, created_at)
+SELECT id, group_id, study_activity_id, created_at FROM study_sessions;
+DROP TABLE study_sessions;
+ALTER TABLE study_sessions_new RENAME TO study_sessions;
+
+-- Reviews belong to their word, but keep their activity
+CREATE TABLE word_review_items_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL,
+    study_activity_id INTEGER NOT NULL,
+    correct BOOLEAN NOT NULL,
+    response_time REAL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    FOREIGN KEY (study_activity_id) REFERENCES study_activities(id) ON DELETE RESTRICT
+);
+INSERT INTO word_review_items_new (id, word_id, study_activity_id, correct, response_time, created_at)
+SELECT id, word_id, study_activity_id, correct, response_time, created_at FROM word_review_items;
+DROP TABLE word_review_items;
+ALTER TABLE word_review_items_new RENAME TO word_review_items;
+
+-- Media rows belong to their word. Images outlive their links and are
+-- removed by the store once orphaned.
+CREATE TABLE word_audio_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE
+);
+INSERT INTO word_audio_new (id, word_id, filename, content_type, size, created_at)
+SELECT id, word_id, filename, content_type, size, created_at FROM word_audio;
+DROP TABLE word_audio;
+ALTER TABLE word_audio_new RENAME TO word_audio;
+
+CREATE TABLE word_images_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL,
+    image_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    FOREIGN KEY (image_id) REFERENCES images(id) ON DELETE RESTRICT
+);
+INSERT INTO word_images_new (id, word_id, image_id, created_at)
+SELECT id, word_id, image_id, created_at FROM word_images;
+DROP TABLE word_images;
+ALTER TABLE word_images_new RENAME TO word_images;
+
+CREATE TABLE word_tags_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+INSERT INTO word_tags_new (id, word_id, tag_id, created_at)
+SELECT id, word_id, tag_id, created_at FROM word_tags;
+DROP TABLE word_tags;
+ALTER TABLE word_tags_new RENAME TO word_tags;
+
+-- Recreate the indexes dropped with the old tables
+CREATE UNIQUE INDEX IF NOT EXISTS idx_word_groups_word_group ON word_groups(word_id, group_id);
+CREATE INDEX IF NOT EXISTS idx_word_groups_word_id ON word_groups(word_id);
+CREATE INDEX IF NOT EXISTS idx_word_groups_group_id ON word_groups(group_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_study_activity_groups_activity_group ON study_activity_groups(study_activity_id, group_id);
+CREATE INDEX IF NOT EXISTS idx_study_activity_groups_group_id ON study_activity_groups(group_id);
+CREATE INDEX IF NOT EXISTS idx_study_sessions_group_id ON study_sessions(group_id);
+CREATE INDEX IF NOT EXISTS idx_study_sessions_activity_id ON study_sessions(study_activity_id);
+CREATE INDEX IF NOT EXISTS idx_word_review_items_word_id ON word_review_items(word_id);
+CREATE INDEX IF NOT EXISTS idx_word_review_items_activity_id ON word_review_items(study_activity_id);
+CREATE INDEX IF NOT EXISTS idx_word_audio_word_id ON word_audio(word_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_word_images_word_image ON word_images(word_id, image_id);
+CREATE INDEX IF NOT EXISTS idx_word_images_image_id ON word_images(image_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_word_tags_word_tag ON word_tags(word_id, tag_id);
+CREATE INDEX IF NOT EXISTS idx_word_tags_tag_id ON word_tags(tag_id);
@@ -0,0 +1,11 @@
+// Package migrations embeds the SQL migrations, so the server can create and
+// upgrade its database without access to the source tree. SQLite migrations
+// sit at the root of FS and PostgreSQL migrations under postgres/.
+package migrations
+
+import "embed"
+
+// FS holds the *.up.sql and *.down.sql migration files
+//
+//go:embed *.sql postgres/*.sql
+var FS embed.FS
@@ -0,0 +1,75 @@
+-- Create words table
+CREATE TABLE IF NOT EXISTS words (
+    id SERIAL PRIMARY KEY,
+    english TEXT NOT NULL,
+    spanish TEXT NOT NULL,
+    level TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create groups table
+CREATE TABLE IF NOT EXISTS groups (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create word_groups table (many-to-many join table)
+CREATE TABLE IF NOT EXISTS word_groups (
+    id SERIAL PRIMARY KEY,
+    word_id INTEGER NOT NULL,
+    group_id INTEGER NOT NULL,
+    FOREIGN KEY (word_id) REFERENCES words(id),
+    FOREIGN KEY (group_id) REFERENCES groups(id)
+);
+
+-- Create study_activities table
+CREATE TABLE IF NOT EXISTS study_activities (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create study_activity_groups table (many-to-many join table)
+CREATE TABLE IF NOT EXISTS study_activity_groups (
+    id SERIAL PRIMARY KEY,
+    study_activity_id INTEGER NOT NULL,
+    group_id INTEGER NOT NULL,
+    FOREIGN KEY (study_activity_id) REFERENCES study_activities(id),
+    FOREIGN KEY (group_id) REFERENCES groups(id)
+);
+
+-- Create study_sessions table
+CREATE TABLE IF NOT EXISTS study_sessions (
+    id SERIAL PRIMARY KEY,
+    group_id INTEGER NOT NULL,
+    study_activity_id INTEGER NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES groups(id),
+    FOREIGN KEY (study_activity_id) REFERENCES study_activities(id)
+);
+
+-- Create word_review_items table
+CREATE TABLE IF NOT EXISTS word_review_items (
+    id SERIAL PRIMARY KEY,
+    word_id INTEGER NOT NULL,
+    study_activity_id INTEGER NOT NULL,
+    correct BOOLEAN NOT NULL,
+    response_time DOUBLE PRECISION,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (word_id) REFERENCES words(id),
+    FOREIGN KEY (study_activity_id) REFERENCES study_activities(id)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_words_level ON words(level);
+CREATE INDEX IF NOT EXISTS idx_word_groups_word_id ON word_groups(word_id);
+CREATE INDEX IF NOT EXISTS idx_word_groups_group_id ON word_groups(group_id);
+CREATE INDEX IF NOT EXISTS idx_study_sessions_group_id ON study_sessions(group_id);
+CREATE INDEX IF NOT EXISTS idx_study_sessions_activity_id ON study_sessions(study_activity_id);
+CREATE INDEX IF NOT EXISTS idx_word_review_items_word_id ON word_review_items(word_id);
+CREATE INDEX IF NOT EXISTS idx_word_review_items_activity_id ON word_review_items(study_activity_id);
@@ -0,0 +1,13 @@
+-- Create word_audio table (pronunciation clips stored in the media directory)
+CREATE TABLE IF NOT EXISTS word_audio (
+    id SERIAL PRIMARY KEY,
+    word_id INTEGER NOT NULL,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (word_id) REFERENCES words(id)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_word_audio_word_id ON word_audio(word_id);
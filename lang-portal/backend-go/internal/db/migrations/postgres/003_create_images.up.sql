@@ -0,0 +1,26 @@
+-- Create images table (content-addressed files stored in the media directory)
+CREATE TABLE IF NOT EXISTS images (
+    id SERIAL PRIMARY KEY,
+    sha256 TEXT NOT NULL UNIQUE,
+    filename TEXT NOT NULL,
+    thumbnail_filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    size BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create word_images table (many-to-many join table)
+CREATE TABLE IF NOT EXISTS word_images (
+    id SERIAL PRIMARY KEY,
+    word_id INTEGER NOT NULL,
+    image_id INTEGER NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (word_id) REFERENCES words(id),
+    FOREIGN KEY (image_id) REFERENCES images(id)
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_word_images_word_image ON word_images(word_id, image_id);
+CREATE INDEX IF NOT EXISTS idx_word_images_image_id ON word_images(image_id);
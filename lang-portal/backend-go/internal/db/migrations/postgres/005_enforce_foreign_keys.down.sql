@@ -0,0 +1,39 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_study_activity_groups_group_id;
+DROP INDEX IF EXISTS idx_study_activity_groups_activity_group;
+DROP INDEX IF EXISTS idx_word_groups_word_group;
+
+-- Restore the foreign keys without ON DELETE actions
+ALTER TABLE word_groups
+    DROP CONSTRAINT word_groups_word_id_fkey,
+    DROP CONSTRAINT word_groups_group_id_fkey,
+    ADD CONSTRAINT word_groups_word_id_fkey FOREIGN KEY (word_id) REFERENCES words(id),
+    ADD CONSTRAINT word_groups_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups(id);
+ALTER TABLE study_activity_groups
+    DROP CONSTRAINT study_activity_groups_study_activity_id_fkey,
+    DROP CONSTRAINT study_activity_groups_group_id_fkey,
+    ADD CONSTRAINT study_activity_groups_study_activity_id_fkey FOREIGN KEY (study_activity_id) REFERENCES study_activities(id),
+    ADD CONSTRAINT study_activity_groups_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups(id);
+ALTER TABLE study_sessions
+    DROP CONSTRAINT study_sessions_group_id_fkey,
+    DROP CONSTRAINT study_sessions_study_activity_id_fkey,
+    ADD CONSTRAINT study_sessions_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups(id),
+    ADD CONSTRAINT study_sessions_study_activity_id_fkey FOREIGN KEY (study_activity_id) REFERENCES study_activities(id);
+ALTER TABLE word_review_items
+    DROP CONSTRAINT word_review_items_word_id_fkey,
+    DROP CONSTRAINT word_review_items_study_activity_id_fkey,
+    ADD CONSTRAINT word_review_items_word_id_fkey FOREIGN KEY (word_id) REFERENCES words(id),
+    ADD CONSTRAINT word_review_items_study_activity_id_fkey FOREIGN KEY (study_activity_id) REFERENCES study_activities(id);
+ALTER TABLE word_audio
+    DROP CONSTRAINT word_audio_word_id_fkey,
+    ADD CONSTRAINT word_audio_word_id_fkey FOREIGN KEY (word_id) REFERENCES words(id);
+ALTER TABLE word_images
+    DROP CONSTRAINT word_images_word_id_fkey,
+    DROP CONSTRAINT word_images_image_id_fkey,
+    ADD CONSTRAINT word_images_word_id_fkey FOREIGN KEY (word_id) REFERENCES words(id),
+    ADD CONSTRAINT word_images_image_id_fkey FOREIGN KEY (image_id) REFERENCES images(id);
+ALTER TABLE word_tags
+    DROP CONSTRAINT word_tags_word_id_fkey,
+    DROP CONSTRAINT word_tags_tag_id_fkey,
+    ADD CONSTRAINT word_tags_word_id_fkey FOREIGN KEY (word_id) REFERENCES words(id),
+    ADD CONSTRAINT word_tags_tag_id_fkey FOREIGN KEY (tag_id) REFERENCES tags(id);
@@ -0,0 +1,71 @@
+-- Enforce foreign keys with explicit ON DELETE actions and unique join rows,
+-- after repairing rows that would violate them
+
+-- Remove rows referencing missing words, groups, activities, images or tags
+DELETE FROM word_groups
+WHERE word_id NOT IN (SELECT id FROM words) OR group_id NOT IN (SELECT id FROM groups);
+DELETE FROM study_activity_groups
+WHERE study_activity_id NOT IN (SELECT id FROM study_activities) OR group_id NOT IN (SELECT id FROM groups);
+DELETE FROM study_sessions
+WHERE study_activity_id NOT IN (SELECT id FROM study_activities) OR group_id NOT IN (SELECT id FROM groups);
+DELETE FROM word_review_items
+WHERE word_id NOT IN (SELECT id FROM words) OR study_activity_id NOT IN (SELECT id FROM study_activities);
+DELETE FROM word_audio
+WHERE word_id NOT IN (SELECT id FROM words);
+DELETE FROM word_images
+WHERE word_id NOT IN (SELECT id FROM words) OR image_id NOT IN (SELECT id FROM images);
+DELETE FROM word_tags
+WHERE word_id NOT IN (SELECT id FROM words) OR tag_id NOT IN (SELECT id FROM tags);
+
+-- Remove duplicate links, keeping the oldest
+DELETE FROM word_groups
+WHERE id NOT IN (SELECT MIN(id) FROM word_groups GROUP BY word_id, group_id);
+DELETE FROM study_activity_groups
+WHERE id NOT IN (SELECT MIN(id) FROM study_activity_groups GROUP BY study_activity_id, group_id);
+
+-- Links are removed with either side
+ALTER TABLE word_groups
+    DROP CONSTRAINT IF EXISTS word_groups_word_id_fkey,
+    DROP CONSTRAINT IF EXISTS word_groups_group_id_fkey,
+    ADD CONSTRAINT word_groups_word_id_fkey FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    ADD CONSTRAINT word_groups_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE;
+ALTER TABLE study_activity_groups
+    DROP CONSTRAINT IF EXISTS study_activity_groups_study_activity_id_fkey,
+    DROP CONSTRAINT IF EXISTS study_activity_groups_group_id_fkey,
+    ADD CONSTRAINT study_activity_groups_study_activity_id_fkey FOREIGN KEY (study_activity_id) REFERENCES study_activities(id) ON DELETE CASCADE,
+    ADD CONSTRAINT study_activity_groups_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE;
+
+-- Study history keeps its group and activity until the history is reset
+ALTER TABLE study_sessions
+    DROP CONSTRAINT IF EXISTS study_sessions_group_id_fkey,
+    DROP CONSTRAINT IF EXISTS study_sessions_study_activity_id_fkey,
+    ADD CONSTRAINT study_sessions_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT study_sessions_study_activity_id_fkey FOREIGN KEY (study_activity_id) REFERENCES study_activities(id) ON DELETE RESTRICT;
+
+-- Reviews belong to their word, but keep their activity
+ALTER TABLE word_review_items
+    DROP CONSTRAINT IF EXISTS word_review_items_word_id_fkey,
+    DROP CONSTRAINT IF EXISTS word_review_items_study_activity_id_fkey,
+    ADD CONSTRAINT word_review_items_word_id_fkey FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    ADD CONSTRAINT word_review_items_study_activity_id_fkey FOREIGN KEY (study_activity_id) REFERENCES study_activities(id) ON DELETE RESTRICT;
+
+-- Media rows belong to their word. Images outlive their links and are
+-- removed by the store once orphaned.
+ALTER TABLE word_audio
+    DROP CONSTRAINT IF EXISTS word_audio_word_id_fkey,
+    ADD CONSTRAINT word_audio_word_id_fkey FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE;
+ALTER TABLE word_images
+    DROP CONSTRAINT IF EXISTS word_images_word_id_fkey,
+    DROP CONSTRAINT IF EXISTS word_images_image_id_fkey,
+    ADD CONSTRAINT word_images_word_id_fkey FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    ADD CONSTRAINT word_images_image_id_fkey FOREIGN KEY (image_id) REFERENCES images(id) ON DELETE RESTRICT;
+ALTER TABLE word_tags
+    DROP CONSTRAINT IF EXISTS word_tags_word_id_fkey,
+    DROP CONSTRAINT IF EXISTS word_tags_tag_id_fkey,
+    ADD CONSTRAINT word_tags_word_id_fkey FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    ADD CONSTRAINT word_tags_tag_id_fkey FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE;
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_word_groups_word_group ON word_groups(word_id, group_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_study_activity_groups_activity_group ON study_activity_groups(study_activity_id, group_id);
+CREATE INDEX IF NOT EXISTS idx_study_activity_groups_group_id ON study_activity_groups(group_id);
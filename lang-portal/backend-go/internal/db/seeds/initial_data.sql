@@ -9,7 +9,12 @@ INSERT INTO words (english, spanish, level) VALUES
 ('water', 'agua', 'beginner'),
 ('food', 'comida', 'beginner'),
 ('house', 'casa', 'beginner'),
-('car', 'coche', 'beginner');
+('car', 'coche', 'beginner'),
+('to speak', 'hablar', 'beginner'),
+('to eat', 'comer', 'beginner'),
+('to live', 'vivir', 'beginner'),
+('to be', 'ser', 'beginner'),
+('to have', 'tener', 'beginner');
 
 -- Insert sample groups
 INSERT INTO groups (name) VALUES
@@ -17,7 +22,8 @@ INSERT INTO groups (name) VALUES
 ('Common Phrases'),
 ('Food and Drink'),
 ('Transportation'),
-('Home and Family');
+('Home and Family'),
+('Common Verbs');
 
 -- Link words to groups
 INSERT INTO word_groups (word_id, group_id) VALUES
@@ -30,7 +36,12 @@ INSERT INTO word_groups (word_id, group_id) VALUES
 (7, 3), -- water -> Food and Drink
 (8, 3), -- food -> Food and Drink
 (9, 5), -- house -> Home and Family
-(10, 4); -- car -> Transportation
+(10, 4), -- car -> Transportation
+(11, 6), -- to speak -> Common Verbs
+(12, 6), -- to eat -> Common Verbs
+(13, 6), -- to live -> Common Verbs
+(14, 6), -- to be -> Common Verbs
+(15, 6); -- to have -> Common Verbs
 
 -- Insert sample study activities
 INSERT INTO study_activities (name, description) VALUES
@@ -0,0 +1,48 @@
+// Package seeds embeds the seed packs a database can be filled with, so the
+// server can reseed without access to the source tree
+package seeds
+
+import (
+	"embed"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Default is the seed pack new and reset databases are filled with
+const Default = "initial_data"
+
+// None names the choice of resetting to an empty database
+const None = "none"
+
+// ErrUnknown is returned for names that are not seed packs
+var ErrUnknown = errors.New("unknown seed pack")
+
+//go:embed *.sql
+var files embed.FS
+
+// Names lists the seed packs, in alphabetical order
+func Names() []string {
+	entries, _ := files.ReadDir(".")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".sql"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load returns the SQL of a seed pack, or an empty string for None
+func Load(name string) (string, error) {
+	if name == None {
+		return "", nil
+	}
+	if name == "" || strings.ContainsAny(name, `/\.`) {
+		return "", ErrUnknown
+	}
+	contents, err := files.ReadFile(name + ".sql")
+	if err != nil {
+		return "", ErrUnknown
+	}
+	return string(contents), nil
+}
@@ -0,0 +1,36 @@
+-- Insert travel words
+INSERT INTO words (english, spanish, level) VALUES
+('airport', 'aeropuerto', 'beginner'),
+('ticket', 'billete', 'beginner'),
+('passport', 'pasaporte', 'beginner'),
+('hotel', 'hotel', 'beginner'),
+('room', 'habitación', 'beginner'),
+('train station', 'estación de tren', 'intermediate'),
+('luggage', 'equipaje', 'intermediate'),
+('to travel', 'viajar', 'beginner'),
+('to arrive', 'llegar', 'beginner'),
+('to leave', 'salir', 'beginner');
+
+-- Insert travel groups
+INSERT INTO groups (name) VALUES
+('At the Airport'),
+('Travel Verbs');
+
+-- Link words to groups
+INSERT INTO word_groups (word_id, group_id) VALUES
+(1, 1), -- airport -> At the Airport
+(2, 1), -- ticket -> At the Airport
+(3, 1), -- passport -> At the Airport
+(7, 1), -- luggage -> At the Airport
+(8, 2), -- to travel -> Travel Verbs
+(9, 2), -- to arrive -> Travel Verbs
+(10, 2); -- to leave -> Travel Verbs
+
+-- Insert travel study activities
+INSERT INTO study_activities (name, description) VALUES
+('Vocabulary Practice', 'Practice vocabulary words with flashcards');
+
+-- Link study activities to groups
+INSERT INTO study_activity_groups (study_activity_id, group_id) VALUES
+(1, 1), -- Vocabulary Practice -> At the Airport
+(1, 2); -- Vocabulary Practice -> Travel Verbs
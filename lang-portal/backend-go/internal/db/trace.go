@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates a span for each statement. It defers to the global tracer
+// provider, so spans are dropped unless tracing is configured.
+var tracer = otel.Tracer("github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db")
+
+var (
+	stringLiteral  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	numericLiteral = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+)
+
+// sanitize replaces the literals in a statement with ? and collapses its
+// whitespace, so span attributes never carry data. Values bound as arguments
+// are not part of the text to begin with.
+func sanitize(query string) string {
+	query = stringLiteral.ReplaceAllString(query, "?")
+	query = numericLiteral.ReplaceAllString(query, "?")
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// startSpan starts the span of a statement, named after its first keyword.
+// The query is only sanitized for spans that are recorded, so statements
+// cost next to nothing extra while tracing is off.
+func startSpan(ctx context.Context, dialect Dialect, query string) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, keyword(query), trace.WithSpanKind(trace.SpanKindClient))
+	if span.IsRecording() {
+		system := "sqlite"
+		if dialect == Postgres {
+			system = "postgresql"
+		}
+		span.SetAttributes(
+			attribute.String("db.system", system),
+			attribute.String("db.query.text", sanitize(query)),
+		)
+	}
+	return ctx, span
+}
+
+// keyword returns the first word of a statement in upper case, or SQL if it
+// is empty
+func keyword(query string) string {
+	query = strings.TrimLeftFunc(query, unicode.IsSpace)
+	if end := strings.IndexFunc(query, unicode.IsSpace); end >= 0 {
+		query = query[:end]
+	}
+	if query == "" {
+		return "SQL"
+	}
+	return strings.ToUpper(query)
+}
+
+// endSpan records the rows a statement returned or affected, and its error,
+// and ends its span
+func endSpan(span trace.Span, key string, rows int64, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(attribute.Int64(key, rows))
+	}
+	span.End()
+}
+
+// Rows is the result of a query. Its span ends when the rows are closed,
+// recording how many were read.
+type Rows struct {
+	*sql.Rows
+	span  trace.Span
+	count int64
+}
+
+// Next prepares the next row for Scan, counting it
+func (r *Rows) Next() bool {
+	if r.Rows.Next() {
+		r.count++
+		return true
+	}
+	return false
+}
+
+// Close closes the rows and ends the query's span
+func (r *Rows) Close() error {
+	err := r.Rows.Close()
+	if r.span != nil {
+		spanErr := r.Rows.Err()
+		if spanErr == nil {
+			spanErr = err
+		}
+		endSpan(r.span, "db.response.returned_rows", r.count, spanErr)
+		r.span = nil
+	}
+	return err
+}
+
+// Row is the result of a query for at most one row. Its span ends when the
+// row is scanned.
+type Row struct {
+	*sql.Row
+	span trace.Span
+}
+
+// Scan copies the row's columns into dest and ends the query's span
+func (r *Row) Scan(dest ...interface{}) error {
+	err := r.Row.Scan(dest...)
+	if r.span != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			endSpan(r.span, "db.response.returned_rows", 0, nil)
+		default:
+			endSpan(r.span, "db.response.returned_rows", 1, err)
+		}
+		r.span = nil
+	}
+	return err
+}
+
+// execer is implemented by *sql.DB, *sql.Conn and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// traceExec runs a statement in a span recording the rows it affected
+func traceExec(ctx context.Context, e execer, dialect Dialect, query string, args []interface{}) (sql.Result, error) {
+	ctx, span := startSpan(ctx, dialect, query)
+	result, err := e.ExecContext(ctx, dialect.Rebind(query), args...)
+	var affected int64
+	if err == nil {
+		affected, _ = result.RowsAffected()
+	}
+	endSpan(span, "db.response.affected_rows", affected, err)
+	return result, err
+}
+
+// traceQuery runs a query in a span that ends when its rows are closed
+func traceQuery(ctx context.Context, e execer, dialect Dialect, query string, args []interface{}) (*Rows, error) {
+	ctx, span := startSpan(ctx, dialect, query)
+	rows, err := e.QueryContext(ctx, dialect.Rebind(query), args...)
+	if err != nil {
+		endSpan(span, "db.response.returned_rows", 0, err)
+		return nil, err
+	}
+	return &Rows{Rows: rows, span: span}, nil
+}
+
+// traceQueryRow runs a query for one row in a span that ends when it is scanned
+func traceQueryRow(ctx context.Context, e execer, dialect Dialect, query string, args []interface{}) *Row {
+	ctx, span := startSpan(ctx, dialect, query)
+	return &Row{Row: e.QueryRowContext(ctx, dialect.Rebind(query), args...), span: span}
+}
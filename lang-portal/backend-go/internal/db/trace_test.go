@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var (
+	recorderOnce sync.Once
+	recorder     *tracetest.SpanRecorder
+)
+
+// recordSpans installs a global tracer provider recording every span. The
+// package tracer only ever delegates to the first provider set, so it is
+// shared by all tests.
+func recordSpans() *tracetest.SpanRecorder {
+	recorderOnce.Do(func() {
+		recorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	})
+	return recorder
+}
+
+// spanAttrs returns the attributes of the last span ended
+func spanAttrs(t *testing.T, rec *tracetest.SpanRecorder) (string, map[attribute.Key]attribute.Value) {
+	t.Helper()
+	ended := rec.Ended()
+	if len(ended) == 0 {
+		t.Fatal("Expected a span to have ended")
+	}
+	span := ended[len(ended)-1]
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return span.Name(), attrs
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT * FROM words WHERE id = ?", "SELECT * FROM words WHERE id = ?"},
+		{"SELECT *\n\t FROM words\n WHERE kanji = 'it''s' LIMIT 10", "SELECT * FROM words WHERE kanji = ? LIMIT ?"},
+		{"UPDATE words SET score = 0.5 WHERE id IN (1, 2)", "UPDATE words SET score = ? WHERE id IN (?, ?)"},
+		{"SELECT t1.id FROM word_groups t1", "SELECT t1.id FROM word_groups t1"},
+	}
+
+	for _, tt := range tests {
+		if got := sanitize(tt.query); got != tt.want {
+			t.Errorf("sanitize(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestKeyword(t *testing.T) {
+	tests := map[string]string{
+		"\n\t\tselect id FROM words": "SELECT",
+		"DELETE FROM words":          "DELETE",
+		"PRAGMA foreign_keys":        "PRAGMA",
+		"  ":                         "SQL",
+	}
+	for query, want := range tests {
+		if got := keyword(query); got != want {
+			t.Errorf("keyword(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
+
+func TestTraceStatements(t *testing.T) {
+	// Setup
+	rec := recordSpans()
+	conn, err := Connect(filepath.Join(t.TempDir(), "test.db"), Options{MaxOpenConns: 1})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+
+	// Test exec spans record the rows affected
+	if _, err := conn.ExecContext(ctx, "CREATE TABLE words (id INTEGER PRIMARY KEY, kanji TEXT)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, "INSERT INTO words (kanji) VALUES ('一'), ('二'), (?)", "三"); err != nil {
+		t.Fatalf("Failed to insert words: %v", err)
+	}
+	name, attrs := spanAttrs(t, rec)
+	if name != "INSERT" || attrs["db.response.affected_rows"].AsInt64() != 3 || attrs["db.system"].AsString() != "sqlite" {
+		t.Errorf("Expected an INSERT span affecting 3 rows, got %s %v", name, attrs)
+	}
+	if text := attrs["db.query.text"].AsString(); text != "INSERT INTO words (kanji) VALUES (?), (?), (?)" {
+		t.Errorf("Expected sanitized query text, got %q", text)
+	}
+
+	// Test query spans record the rows read once closed
+	rows, err := conn.QueryContext(ctx, "SELECT id FROM words WHERE id > 1")
+	if err != nil {
+		t.Fatalf("Failed to query words: %v", err)
+	}
+	for rows.Next() {
+	}
+	rows.Close()
+	name, attrs = spanAttrs(t, rec)
+	if name != "SELECT" || attrs["db.response.returned_rows"].AsInt64() != 2 {
+		t.Errorf("Expected a SELECT span returning 2 rows, got %s %v", name, attrs)
+	}
+
+	// Test single row spans record whether a row was found
+	var id int64
+	err = conn.QueryRowContext(ctx, "SELECT id FROM words WHERE kanji = ?", "四").Scan(&id)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("Expected no rows, got %v", err)
+	}
+	if _, attrs = spanAttrs(t, rec); attrs["db.response.returned_rows"].AsInt64() != 0 {
+		t.Errorf("Expected no rows returned, got %v", attrs)
+	}
+}
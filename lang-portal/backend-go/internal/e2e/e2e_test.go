@@ -9,6 +9,9 @@ import (
 	"time"
 
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/api"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/config"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/store"
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
 	"github.com/gin-gonic/gin"
 )
@@ -22,43 +25,32 @@ func encodeJSON(t *testing.T, data interface{}) *bytes.Buffer {
 	return buf
 }
 
-func setupRouter() *gin.Engine {
-	r := testutil.SetupTestRouter()
-
-	// Groups
-	r.GET("/api/groups", api.GetGroups)
-	r.GET("/api/groups/:id", api.GetGroup)
-	r.POST("/api/groups", api.CreateGroup)
-
-	// Study Activities
-	r.GET("/api/study_activities/:id", api.GetStudyActivity)
-	r.POST("/api/study_activities", api.CreateStudyActivity)
-	r.GET("/api/study_activities/:id/sessions", api.GetStudyActivitySessions)
-
-	// Study Sessions
-	r.GET("/api/study_sessions", api.GetStudySessions)
-	r.POST("/api/study_sessions", api.CreateStudySession)
-	r.GET("/api/study_sessions/:id", api.GetStudySession)
-	r.GET("/api/study_sessions/:id/words", api.GetStudySessionWords)
-	r.POST("/api/study_sessions/:id/words/:word_id/review", api.CreateWordReview)
-
-	// Dashboard
-	r.GET("/api/dashboard/last_session", api.GetLastStudySession)
-	r.GET("/api/dashboard/progress", api.GetStudyProgress)
-	r.GET("/api/dashboard/quick_stats", api.GetQuickStats)
-
-	// System
-	r.POST("/api/reset_history", api.ResetHistory)
+// decodeItems decodes the items of a paginated list response
+func decodeItems(data []byte, v interface{}) error {
+	var page struct {
+		Items json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(data, &page); err != nil {
+		return err
+	}
+	return json.Unmarshal(page.Items, v)
+}
 
+// setupRouter returns a router serving the API from a fresh test database
+func setupRouter(t *testing.T) *gin.Engine {
+	cfg := config.Default()
+	cfg.MediaDir = t.TempDir()
+	cfg.BackupDir = t.TempDir()
+	s := api.NewServer(store.NewSQLite(testutil.NewTestDB(t), media.Dir(cfg.MediaDir)), cfg)
+	r := testutil.SetupTestRouter()
+	s.RegisterRoutes(r)
 	return r
 }
 
 func TestCompleteUserFlow(t *testing.T) {
+	t.Parallel()
 	// Setup
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
-
-	r := setupRouter()
+	r := setupRouter(t)
 
 	// Step 1: Create a study activity
 	activityPayload := map[string]interface{}{
@@ -110,13 +102,13 @@ func TestCompleteUserFlow(t *testing.T) {
 	}
 
 	// Step 4: Get study sessions for the activity
-	w = testutil.MakeRequest(r, "GET", fmt.Sprintf("/api/study_activities/%d/sessions", activity.ID), nil)
+	w = testutil.MakeRequest(r, "GET", fmt.Sprintf("/api/study_activities/%d/study_sessions", activity.ID), nil)
 	testutil.AssertStatus(t, w, http.StatusOK)
 
 	var sessions []struct {
 		ID int `json:"id"`
 	}
-	err = json.Unmarshal(w.Body.Bytes(), &sessions)
+	err = decodeItems(w.Body.Bytes(), &sessions)
 	if err != nil {
 		t.Fatalf("Failed to parse sessions response: %v", err)
 	}
@@ -133,7 +125,7 @@ func TestCompleteUserFlow(t *testing.T) {
 	var words []struct {
 		ID int `json:"id"`
 	}
-	err = json.Unmarshal(w.Body.Bytes(), &words)
+	err = decodeItems(w.Body.Bytes(), &words)
 	if err != nil {
 		t.Fatalf("Failed to parse words response: %v", err)
 	}
@@ -155,7 +147,7 @@ func TestCompleteUserFlow(t *testing.T) {
 	}
 
 	// Step 6: Check study progress
-	w = testutil.MakeRequest(r, "GET", "/api/dashboard/progress", nil)
+	w = testutil.MakeRequest(r, "GET", "/api/dashboard/study_progress", nil)
 	testutil.AssertStatus(t, w, http.StatusOK)
 
 	var progress struct {
@@ -214,11 +206,9 @@ func TestCompleteUserFlow(t *testing.T) {
 }
 
 func TestE2E_MultipleSessions(t *testing.T) {
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
-
+	t.Parallel()
 	// Reset history to clear any existing sessions
-	router := setupRouter()
+	router := setupRouter(t)
 	resetResponse := testutil.MakeRequest(router, "POST", "/api/reset_history", bytes.NewReader(nil))
 	testutil.AssertStatus(t, resetResponse, http.StatusOK)
 
@@ -229,7 +219,7 @@ func TestE2E_MultipleSessions(t *testing.T) {
 	var groups []struct {
 		ID int `json:"id"`
 	}
-	if err := json.Unmarshal(groupResponse.Body.Bytes(), &groups); err != nil {
+	if err := decodeItems(groupResponse.Body.Bytes(), &groups); err != nil {
 		t.Fatalf("Failed to parse groups response: %v", err)
 	}
 
@@ -284,7 +274,7 @@ func TestE2E_MultipleSessions(t *testing.T) {
 	}
 
 	var sessions []map[string]interface{}
-	if err := json.Unmarshal(response.Body.Bytes(), &sessions); err != nil {
+	if err := decodeItems(response.Body.Bytes(), &sessions); err != nil {
 		t.Logf("Response body: %s", response.Body.String())
 		t.Fatalf("Failed to parse response: %v", err)
 	}
@@ -295,10 +285,8 @@ func TestE2E_MultipleSessions(t *testing.T) {
 }
 
 func TestE2E_InvalidActivityData(t *testing.T) {
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
-
-	router := setupRouter()
+	t.Parallel()
+	router := setupRouter(t)
 
 	// Create study activity with invalid data (missing required fields)
 	activityData := map[string]interface{}{
@@ -310,10 +298,8 @@ func TestE2E_InvalidActivityData(t *testing.T) {
 }
 
 func TestE2E_PaginationLimits(t *testing.T) {
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
-
-	router := setupRouter()
+	t.Parallel()
+	router := setupRouter(t)
 
 	// Get existing group from database
 	groupResponse := testutil.MakeRequest(router, "GET", "/api/groups", nil)
@@ -322,7 +308,7 @@ func TestE2E_PaginationLimits(t *testing.T) {
 	var groups []struct {
 		ID int `json:"id"`
 	}
-	if err := json.Unmarshal(groupResponse.Body.Bytes(), &groups); err != nil {
+	if err := decodeItems(groupResponse.Body.Bytes(), &groups); err != nil {
 		t.Fatalf("Failed to parse groups response: %v", err)
 	}
 
@@ -377,7 +363,7 @@ func TestE2E_PaginationLimits(t *testing.T) {
 	}
 
 	var sessions []map[string]interface{}
-	if err := json.Unmarshal(response.Body.Bytes(), &sessions); err != nil {
+	if err := decodeItems(response.Body.Bytes(), &sessions); err != nil {
 		t.Logf("Response body: %s", response.Body.String())
 		t.Fatalf("Failed to parse response: %v", err)
 	}
@@ -395,7 +381,7 @@ func TestE2E_PaginationLimits(t *testing.T) {
 		t.Fatal("Failed to get study sessions with higher limit")
 	}
 
-	if err := json.Unmarshal(response.Body.Bytes(), &sessions); err != nil {
+	if err := decodeItems(response.Body.Bytes(), &sessions); err != nil {
 		t.Logf("Response body: %s", response.Body.String())
 		t.Fatalf("Failed to parse response: %v", err)
 	}
@@ -403,13 +389,50 @@ func TestE2E_PaginationLimits(t *testing.T) {
 	if len(sessions) != 25 {
 		t.Errorf("Expected 25 study sessions, got %d", len(sessions))
 	}
+
+	// Walk every page by following the next cursor
+	seen := make(map[float64]bool)
+	path := "/api/study_sessions?limit=7"
+	for pages := 0; path != ""; pages++ {
+		if pages > 10 {
+			t.Fatal("Pagination did not terminate")
+		}
+		response = testutil.MakeRequest(router, "GET", path, nil)
+		testutil.AssertStatus(t, response, http.StatusOK)
+
+		var page struct {
+			Items      []map[string]interface{} `json:"items"`
+			Pagination struct {
+				NextCursor string `json:"next_cursor"`
+				HasMore    bool   `json:"has_more"`
+			} `json:"pagination"`
+		}
+		if err := json.Unmarshal(response.Body.Bytes(), &page); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		for _, session := range page.Items {
+			id := session["id"].(float64)
+			if seen[id] {
+				t.Errorf("Session %v returned on more than one page", id)
+			}
+			seen[id] = true
+		}
+
+		path = ""
+		if page.Pagination.HasMore {
+			path = "/api/study_sessions?limit=7&cursor=" + page.Pagination.NextCursor
+		}
+	}
+
+	// 30 created sessions plus the 2 seeded ones
+	if len(seen) != 32 {
+		t.Errorf("Expected to page through 32 study sessions, got %d", len(seen))
+	}
 }
 
 func TestE2E_ResetHistoryNoData(t *testing.T) {
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
-
-	router := setupRouter()
+	t.Parallel()
+	router := setupRouter(t)
 
 	// Reset history when there is no data
 	response := testutil.MakeRequest(router, "POST", "/api/reset_history", bytes.NewReader(nil))
@@ -428,7 +451,7 @@ func TestE2E_ResetHistoryNoData(t *testing.T) {
 	}
 
 	var sessions []map[string]interface{}
-	if err := json.Unmarshal(response.Body.Bytes(), &sessions); err != nil {
+	if err := decodeItems(response.Body.Bytes(), &sessions); err != nil {
 		t.Logf("Response body: %s", response.Body.String())
 		t.Fatalf("Failed to parse response: %v", err)
 	}
@@ -439,10 +462,8 @@ func TestE2E_ResetHistoryNoData(t *testing.T) {
 }
 
 func TestE2E_GroupOperations(t *testing.T) {
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
-
-	router := setupRouter()
+	t.Parallel()
+	router := setupRouter(t)
 
 	// Create a new group
 	groupData := map[string]interface{}{
@@ -474,10 +495,8 @@ func TestE2E_GroupOperations(t *testing.T) {
 }
 
 func TestE2E_StudyActivityOperations(t *testing.T) {
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
-
-	router := setupRouter()
+	t.Parallel()
+	router := setupRouter(t)
 
 	// Create a new group first
 	groupData := map[string]interface{}{
@@ -523,11 +542,11 @@ func TestE2E_StudyActivityOperations(t *testing.T) {
 	}
 
 	// Get activity sessions
-	response = testutil.MakeRequest(router, "GET", fmt.Sprintf("/api/study_activities/%d/sessions", activity.ID), bytes.NewReader(nil))
+	response = testutil.MakeRequest(router, "GET", fmt.Sprintf("/api/study_activities/%d/study_sessions", activity.ID), bytes.NewReader(nil))
 	testutil.AssertStatus(t, response, http.StatusOK)
 
 	var sessions []map[string]interface{}
-	if err := json.Unmarshal(response.Body.Bytes(), &sessions); err != nil {
+	if err := decodeItems(response.Body.Bytes(), &sessions); err != nil {
 		t.Fatalf("Failed to parse sessions response: %v", err)
 	}
 
@@ -537,10 +556,8 @@ func TestE2E_StudyActivityOperations(t *testing.T) {
 }
 
 func TestE2E_StudySessionOperations(t *testing.T) {
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
-
-	router := setupRouter()
+	t.Parallel()
+	router := setupRouter(t)
 
 	// Create a group and activity first
 	groupData := map[string]interface{}{
@@ -605,7 +622,7 @@ func TestE2E_StudySessionOperations(t *testing.T) {
 	testutil.AssertStatus(t, response, http.StatusOK)
 
 	var words []map[string]interface{}
-	if err := json.Unmarshal(response.Body.Bytes(), &words); err != nil {
+	if err := decodeItems(response.Body.Bytes(), &words); err != nil {
 		t.Fatalf("Failed to parse words response: %v", err)
 	}
 
@@ -624,10 +641,8 @@ func TestE2E_StudySessionOperations(t *testing.T) {
 }
 
 func TestE2E_DashboardEndpoints(t *testing.T) {
-	cleanup := testutil.SetupTestDB(t)
-	defer cleanup()
-
-	router := setupRouter()
+	t.Parallel()
+	router := setupRouter(t)
 
 	// Create test data first
 	groupData := map[string]interface{}{
@@ -672,7 +687,7 @@ func TestE2E_DashboardEndpoints(t *testing.T) {
 	testutil.AssertStatus(t, response, http.StatusCreated)
 
 	// Test last session endpoint
-	response = testutil.MakeRequest(router, "GET", "/api/dashboard/last_session", bytes.NewReader(nil))
+	response = testutil.MakeRequest(router, "GET", "/api/dashboard/last_study_session", bytes.NewReader(nil))
 	testutil.AssertStatus(t, response, http.StatusOK)
 
 	var lastSession map[string]interface{}
@@ -681,7 +696,7 @@ func TestE2E_DashboardEndpoints(t *testing.T) {
 	}
 
 	// Test progress endpoint
-	response = testutil.MakeRequest(router, "GET", "/api/dashboard/progress", bytes.NewReader(nil))
+	response = testutil.MakeRequest(router, "GET", "/api/dashboard/study_progress", bytes.NewReader(nil))
 	testutil.AssertStatus(t, response, http.StatusOK)
 
 	var progress map[string]interface{}
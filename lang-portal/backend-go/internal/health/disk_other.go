@@ -0,0 +1,8 @@
+//go:build !(linux || darwin || freebsd || windows)
+
+package health
+
+// freeSpace is not implemented on this platform
+func freeSpace(path string) (uint64, error) {
+	return 0, errUnsupported
+}
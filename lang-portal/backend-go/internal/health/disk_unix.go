@@ -0,0 +1,15 @@
+//go:build linux || darwin || freebsd
+
+package health
+
+import "syscall"
+
+// freeSpace returns the bytes available to unprivileged users on the file
+// system holding path
+func freeSpace(path string) (uint64, error) {
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(path, &fs); err != nil {
+		return 0, err
+	}
+	return uint64(fs.Bavail) * uint64(fs.Bsize), nil
+}
@@ -0,0 +1,176 @@
+// Package health serves the liveness and readiness probes. Readiness runs a
+// set of named checks and reports each one's outcome.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/gin-gonic/gin"
+)
+
+// Statuses of a check and of the report as a whole
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+)
+
+// Check inspects one dependency. It returns details worth reporting either
+// way, and an error if the dependency is not fit to serve traffic.
+type Check func(ctx context.Context) (map[string]interface{}, error)
+
+// Result is the outcome of a check
+type Result struct {
+	Status   string                 `json:"status"`
+	Error    string                 `json:"error,omitempty"`
+	Duration string                 `json:"duration"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+}
+
+// Report is the outcome of every check, degraded if any of them failed
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Checker runs the readiness checks
+type Checker struct {
+	timeout time.Duration
+	checks  map[string]Check
+}
+
+// NewChecker returns a Checker that gives each check up to timeout
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout, checks: map[string]Check{}}
+}
+
+// Add registers a check under name, replacing any check of the same name
+func (h *Checker) Add(name string, check Check) {
+	h.checks[name] = check
+}
+
+// Run runs every check in name order
+func (h *Checker) Run(ctx context.Context) Report {
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(names))}
+	for _, name := range names {
+		checkCtx, cancel := context.WithTimeout(ctx, h.timeout)
+		start := time.Now()
+		details, err := h.checks[name](checkCtx)
+		cancel()
+
+		result := Result{Status: StatusOK, Duration: time.Since(start).String(), Details: details}
+		if err != nil {
+			result.Status = StatusDegraded
+			result.Error = err.Error()
+			report.Status = StatusDegraded
+		}
+		report.Checks[name] = result
+	}
+	return report
+}
+
+// Live answers the liveness probe: the process is up and serving requests
+func Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": StatusOK})
+}
+
+// Ready answers the readiness probe with the report of every check, and 503
+// Service Unavailable when any of them failed
+func (h *Checker) Ready(c *gin.Context) {
+	report := h.Run(c.Request.Context())
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
+// Ping checks that every pool can reach the database
+func Ping(pools map[string]*sql.DB) Check {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		details := make(map[string]interface{}, len(pools))
+		var failed error
+		for name, pool := range pools {
+			stats := pool.Stats()
+			details[name] = map[string]interface{}{
+				"open_connections": stats.OpenConnections,
+				"in_use":           stats.InUse,
+			}
+			if err := pool.PingContext(ctx); err != nil && failed == nil {
+				failed = fmt.Errorf("%s pool: %v", name, err)
+			}
+		}
+		return details, failed
+	}
+}
+
+// SchemaVersion checks that the latest migration applied to the database is
+// the expected one. Pass the pool used for reads, so the check is not queued
+// behind a long write such as a full reset.
+func SchemaVersion(conn *sql.DB, dialect db.Dialect, expected int) Check {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		version, err := db.SchemaVersion(ctx, conn, dialect)
+		if err != nil {
+			return nil, err
+		}
+		details := map[string]interface{}{"version": version, "expected": expected}
+		if version != expected {
+			return details, fmt.Errorf("schema is at version %d, expected %d", version, expected)
+		}
+		return details, nil
+	}
+}
+
+// errUnsupported is returned by freeSpace on platforms where free disk space
+// cannot be read
+var errUnsupported = errors.New("free disk space is not available on this platform")
+
+// DiskSpace checks that the file system holding path has at least minFree
+// bytes available. A path that does not exist yet, such as a media directory
+// before the first upload, is checked through its nearest existing parent.
+// On platforms where free space cannot be read the check passes, reporting
+// itself unsupported, rather than keeping the server out of service.
+func DiskSpace(path string, minFree uint64) Check {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		free, err := freeSpace(existingParent(path))
+		if errors.Is(err, errUnsupported) {
+			return map[string]interface{}{"path": path, "unsupported": true}, nil
+		}
+		if err != nil {
+			return map[string]interface{}{"path": path}, err
+		}
+		details := map[string]interface{}{"path": path, "free_bytes": free, "min_free_bytes": minFree}
+		if free < minFree {
+			return details, fmt.Errorf("%d bytes free, below the minimum of %d", free, minFree)
+		}
+		return details, nil
+	}
+}
+
+// existingParent returns path, or its nearest ancestor that exists
+func existingParent(path string) string {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
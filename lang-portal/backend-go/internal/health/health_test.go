@@ -0,0 +1,126 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
+)
+
+// openDB opens an empty SQLite database
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	conn, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestReady(t *testing.T) {
+	// Setup
+	checker := NewChecker(time.Second)
+	checker.Add("passing", func(ctx context.Context) (map[string]interface{}, error) {
+		return map[string]interface{}{"answer": 42}, nil
+	})
+	r := testutil.SetupTestRouter()
+	r.GET("/healthz", Live)
+	r.GET("/readyz", checker.Ready)
+
+	// Test liveness
+	w := testutil.MakeRequest(r, "GET", "/healthz", nil)
+	testutil.AssertStatus(t, w, http.StatusOK)
+
+	// Test ready
+	w = testutil.MakeRequest(r, "GET", "/readyz", nil)
+	testutil.AssertStatus(t, w, http.StatusOK)
+	var report Report
+	testutil.ParseResponse(t, w, &report)
+	if report.Status != StatusOK || report.Checks["passing"].Details["answer"] != float64(42) {
+		t.Errorf("Unexpected report %+v", report)
+	}
+
+	// Test a failing check degrades the report
+	checker.Add("failing", func(ctx context.Context) (map[string]interface{}, error) {
+		return nil, errors.New("broken")
+	})
+	w = testutil.MakeRequest(r, "GET", "/readyz", nil)
+	testutil.AssertStatus(t, w, http.StatusServiceUnavailable)
+	testutil.ParseResponse(t, w, &report)
+	if report.Status != StatusDegraded || report.Checks["failing"].Error != "broken" || report.Checks["passing"].Status != StatusOK {
+		t.Errorf("Unexpected report %+v", report)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	// Setup a check that waits for its context
+	checker := NewChecker(10 * time.Millisecond)
+	checker.Add("hung", func(ctx context.Context) (map[string]interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	// Test
+	report := checker.Run(context.Background())
+	if report.Status != StatusDegraded || !strings.Contains(report.Checks["hung"].Error, "deadline") {
+		t.Errorf("Expected the hung check to time out, got %+v", report)
+	}
+}
+
+func TestChecks(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	conn := openDB(t)
+
+	// Test ping
+	if _, err := Ping(map[string]*sql.DB{"read_write": conn})(ctx); err != nil {
+		t.Errorf("Expected ping to pass, got %v", err)
+	}
+
+	// Test schema version
+	if _, err := SchemaVersion(conn, db.SQLite, 0)(ctx); err != nil {
+		t.Errorf("Expected an empty database to be at version 0, got %v", err)
+	}
+	details, err := SchemaVersion(conn, db.SQLite, 5)(ctx)
+	if err == nil || details["version"] != 0 || details["expected"] != 5 {
+		t.Errorf("Expected a version mismatch, got %v (%v)", details, err)
+	}
+
+	// Test the schema check gives up when its pool is busy
+	conn.SetMaxOpenConns(1)
+	busy, err := conn.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Failed to hold the connection: %v", err)
+	}
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	_, err = SchemaVersion(conn, db.SQLite, 0)(timeout)
+	cancel()
+	busy.Close()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the check to time out, got %v", err)
+	}
+
+	// Test disk space, including a directory that does not exist yet
+	dir := filepath.Join(t.TempDir(), "media", "images")
+	if _, err := DiskSpace(dir, 1)(ctx); err != nil {
+		t.Errorf("Expected free space, got %v", err)
+	}
+	if _, err := DiskSpace(dir, 1<<62)(ctx); err == nil {
+		t.Error("Expected too little free space")
+	}
+
+	// Test ping of a closed pool
+	closed := openDB(t)
+	closed.Close()
+	if _, err := Ping(map[string]*sql.DB{"read": closed})(ctx); err == nil || !strings.Contains(err.Error(), "read pool") {
+		t.Errorf("Expected ping to fail, got %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+// Package limits protects the API from clients sending too many requests or
+// too large a body
+package limits
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/gin-gonic/gin"
+)
+
+// Class groups routes sharing a rate limit
+type Class string
+
+const (
+	Read  Class = "read"
+	Write Class = "write"
+	Admin Class = "admin"
+)
+
+// Rates are the requests per minute each client may make to each class of
+// route. A rate of 0 leaves the class unlimited.
+type Rates struct {
+	Read  int
+	Write int
+	Admin int
+}
+
+// KeyHeader is the header a client may send its API key in, instead of as
+// an Authorization bearer token
+const KeyHeader = "X-API-Key"
+
+// maxBuckets caps the number of buckets kept. Once it is reached the least
+// recently used bucket is dropped for each new one.
+const maxBuckets = 10000
+
+// bucket is a token bucket. tokens is the number left at last.
+type bucket struct {
+	key    bucketKey
+	tokens float64
+	last   time.Time
+}
+
+type bucketKey struct {
+	class  Class
+	client string
+}
+
+// RateLimiter limits each client with a token bucket per class of route.
+// A bucket holds a minute's worth of requests and refills at the class's
+// rate, so clients may burst up to their rate and then keep to it. Buckets
+// are kept in order of use, most recent first, so idle ones are dropped
+// from the back without scanning the rest.
+type RateLimiter struct {
+	rates   Rates
+	keys    map[[sha256.Size]byte]string
+	isAdmin func(path string) bool
+	now     func() time.Time
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*list.Element
+	order   *list.List
+}
+
+// NewRateLimiter returns a limiter applying rates, counting requests to paths
+// isAdmin accepts as admin requests. Clients sending one of apiKeys are
+// limited by their key rather than their IP address.
+func NewRateLimiter(rates Rates, apiKeys []string, isAdmin func(path string) bool) *RateLimiter {
+	// Keys are looked up by hash, so neither the lookup's timing nor the
+	// bucket names give them away
+	keys := make(map[[sha256.Size]byte]string, len(apiKeys))
+	for _, key := range apiKeys {
+		sum := sha256.Sum256([]byte(key))
+		keys[sum] = "key:" + hex.EncodeToString(sum[:8])
+	}
+	return &RateLimiter{
+		rates:   rates,
+		keys:    keys,
+		isAdmin: isAdmin,
+		now:     time.Now,
+		buckets: map[bucketKey]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Middleware rejects requests over the client's rate with 429 Too Many
+// Requests, saying in Retry-After how many seconds to wait
+func (l *RateLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		class := l.classify(c.Request)
+		ok, wait := l.allow(class, l.client(c))
+		if !ok {
+			seconds := int(math.Ceil(wait.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(seconds))
+			problem.Abort(c, problem.RateLimited, fmt.Sprintf("Too many %s requests, retry in %d seconds", class, seconds))
+			return
+		}
+		c.Next()
+	}
+}
+
+// classify returns the class of a request: admin for admin paths, read for
+// safe methods and write for the rest
+func (l *RateLimiter) classify(r *http.Request) Class {
+	switch {
+	case l.isAdmin != nil && l.isAdmin(r.URL.Path):
+		return Admin
+	case r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions:
+		return Read
+	default:
+		return Write
+	}
+}
+
+func (l *RateLimiter) rate(class Class) int {
+	switch class {
+	case Admin:
+		return l.rates.Admin
+	case Write:
+		return l.rates.Write
+	default:
+		return l.rates.Read
+	}
+}
+
+// allow takes a token from the client's bucket for class, or reports how
+// long until one is available
+func (l *RateLimiter) allow(class Class, client string) (bool, time.Duration) {
+	rate := l.rate(class)
+	if rate <= 0 {
+		return true, 0
+	}
+	perSecond := float64(rate) / 60
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.sweep(now)
+
+	key := bucketKey{class, client}
+	var b *bucket
+	if e, ok := l.buckets[key]; ok {
+		b = e.Value.(*bucket)
+		l.order.MoveToFront(e)
+	} else {
+		if l.order.Len() >= maxBuckets {
+			l.remove(l.order.Back())
+		}
+		b = &bucket{key: key, tokens: float64(rate), last: now}
+		l.buckets[key] = l.order.PushFront(b)
+	}
+	b.tokens = math.Min(float64(rate), b.tokens+now.Sub(b.last).Seconds()*perSecond)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / perSecond * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// sweep drops the buckets unused for a minute. They have refilled
+// completely, so a new bucket would behave the same.
+func (l *RateLimiter) sweep(now time.Time) {
+	for e := l.order.Back(); e != nil && now.Sub(e.Value.(*bucket).last) >= time.Minute; e = l.order.Back() {
+		l.remove(e)
+	}
+}
+
+func (l *RateLimiter) remove(e *list.Element) {
+	delete(l.buckets, l.order.Remove(e).(*bucket).key)
+}
+
+// client identifies who is making a request: by its API key, sent in
+// X-API-Key or as a bearer token, when it is one of the limiter's keys, and
+// otherwise by its IP address. Gin only takes the address from
+// X-Forwarded-For when the request comes from one of the engine's trusted
+// proxies, so clients cannot pick their own bucket.
+func (l *RateLimiter) client(c *gin.Context) string {
+	token := c.GetHeader(KeyHeader)
+	if token == "" {
+		if scheme, credentials, ok := strings.Cut(c.GetHeader("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+			token = strings.TrimSpace(credentials)
+		}
+	}
+	if token != "" {
+		if id, ok := l.keys[sha256.Sum256([]byte(token))]; ok {
+			return id
+		}
+	}
+	return c.ClientIP()
+}
+
+// BodySize rejects request bodies larger than max bytes with 413 Request
+// Entity Too Large. Multipart uploads may be up to uploadMax bytes, and the
+// upload handlers cap them further by the kind of file.
+func BodySize(max, uploadMax int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := max
+		if mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type")); mediaType == "multipart/form-data" {
+			limit = uploadMax
+		}
+		if c.Request.ContentLength > limit {
+			problem.Abort(c, problem.BodyTooLarge, fmt.Sprintf("Request body must not exceed %d bytes", limit))
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
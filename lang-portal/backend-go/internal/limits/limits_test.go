@@ -0,0 +1,214 @@
+package limits
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
+	"github.com/gin-gonic/gin"
+)
+
+// newRouter returns a router serving reads, writes and an admin route
+// through the limiter, trusting no proxies as the server does by default
+func newRouter(l *RateLimiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.SetTrustedProxies(nil)
+	r.Use(l.Middleware())
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/api/words", ok)
+	r.POST("/api/study_sessions/:id/words/:word_id/review", ok)
+	r.POST("/api/full_reset", ok)
+	return r
+}
+
+// spoofed counts requests, so each claims a different token and forwarded address
+var spoofed int
+
+// request sends a request from the client at ip, with a new Authorization
+// token and X-Forwarded-For address the limiter must not be fooled by
+func request(r *gin.Engine, method, path, ip string) *httptest.ResponseRecorder {
+	spoofed++
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = ip + ":40000"
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer app-%d", spoofed))
+	req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", spoofed%256))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimiter(t *testing.T) {
+	// Setup a limiter on a fake clock
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(Rates{Read: 0, Write: 2, Admin: 1}, nil, func(path string) bool {
+		return path == "/api/full_reset"
+	})
+	l.now = func() time.Time { return now }
+	r := newRouter(l)
+	review := "/api/study_sessions/1/words/1/review"
+	app := "192.0.2.1"
+
+	// Test a client may burst up to its rate, then is told when to retry,
+	// whatever token or forwarded address it sends
+	for i := 0; i < 2; i++ {
+		if w := request(r, "POST", review, app); w.Code != http.StatusOK {
+			t.Fatalf("Expected review %d to be allowed, got %d", i+1, w.Code)
+		}
+	}
+	w := request(r, "POST", review, app)
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "30" {
+		t.Errorf("Expected 429 retrying in 30 seconds, got %d %q", w.Code, w.Header().Get("Retry-After"))
+	}
+	if !strings.Contains(w.Body.String(), string(problem.RateLimited)) {
+		t.Errorf("Expected a rate_limited problem, got %s", w.Body.String())
+	}
+
+	// Test classes and clients have their own buckets, and 0 means unlimited
+	if w := request(r, "POST", "/api/full_reset", app); w.Code != http.StatusOK {
+		t.Errorf("Expected the admin request to be allowed, got %d", w.Code)
+	}
+	if w := request(r, "POST", review, "192.0.2.2"); w.Code != http.StatusOK {
+		t.Errorf("Expected another client to be allowed, got %d", w.Code)
+	}
+	for i := 0; i < 10; i++ {
+		if w := request(r, "GET", "/api/words", app); w.Code != http.StatusOK {
+			t.Fatalf("Expected reads to be unlimited, got %d", w.Code)
+		}
+	}
+
+	// Test the bucket refills at the rate
+	now = now.Add(30 * time.Second)
+	if w := request(r, "POST", review, app); w.Code != http.StatusOK {
+		t.Errorf("Expected a review after refilling, got %d", w.Code)
+	}
+	if w := request(r, "POST", review, app); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected the refilled token to be used up, got %d", w.Code)
+	}
+
+	// Test idle buckets are swept once full
+	now = now.Add(2 * time.Minute)
+	request(r, "POST", review, "192.0.2.3")
+	if len(l.buckets) != 1 {
+		t.Errorf("Expected only the new bucket after sweeping, got %d", len(l.buckets))
+	}
+
+	// Test the least recently used bucket makes way once the limit is reached
+	for i := 0; i < maxBuckets; i++ {
+		l.allow(Write, fmt.Sprintf("client-%d", i))
+	}
+	if len(l.buckets) != maxBuckets || l.order.Len() != maxBuckets {
+		t.Errorf("Expected %d buckets, got %d", maxBuckets, len(l.buckets))
+	}
+	if _, ok := l.buckets[bucketKey{Write, "192.0.2.3"}]; ok {
+		t.Error("Expected the oldest bucket to be dropped")
+	}
+}
+
+func TestRateLimiterKeys(t *testing.T) {
+	// Setup a limiter knowing two API keys
+	l := NewRateLimiter(Rates{Write: 1}, []string{"key-a", "key-b"}, nil)
+	r := newRouter(l)
+	review := "/api/study_sessions/1/words/1/review"
+	send := func(ip string, header ...string) int {
+		req := httptest.NewRequest("POST", review, nil)
+		req.RemoteAddr = ip + ":40000"
+		if len(header) == 2 {
+			req.Header.Set(header[0], header[1])
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Test a key is limited on its own, from any address and in either header
+	if code := send("192.0.2.1", KeyHeader, "key-a"); code != http.StatusOK {
+		t.Fatalf("Expected the first keyed request to be allowed, got %d", code)
+	}
+	if code := send("192.0.2.2", "Authorization", "Bearer key-a"); code != http.StatusTooManyRequests {
+		t.Errorf("Expected the key's bucket to be used up from another address, got %d", code)
+	}
+	if code := send("192.0.2.1", KeyHeader, "key-b"); code != http.StatusOK {
+		t.Errorf("Expected another key to have its own bucket, got %d", code)
+	}
+
+	// Test requests without a key, or with an unknown one, are limited by IP
+	if code := send("192.0.2.1"); code != http.StatusOK {
+		t.Errorf("Expected the address's own bucket to be unused, got %d", code)
+	}
+	if code := send("192.0.2.1", "Authorization", "Bearer key-c"); code != http.StatusTooManyRequests {
+		t.Errorf("Expected an unknown key to share its address's bucket, got %d", code)
+	}
+	for key := range l.buckets {
+		if strings.Contains(key.client, "key-") {
+			t.Errorf("Expected buckets not to be named after keys, got %q", key.client)
+		}
+	}
+}
+
+func TestBodySize(t *testing.T) {
+	// Setup a router reading bodies through the limit
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(BodySize(16, 512))
+	r.POST("/echo", func(c *gin.Context) {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		c.String(http.StatusOK, "%d", len(data))
+	})
+	post := func(body io.Reader, contentType string, length int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/echo", body)
+		req.Header.Set("Content-Type", contentType)
+		req.ContentLength = length
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test bodies within the limit are read
+	if w := post(strings.NewReader(`{"a": 1}`), "application/json", 8); w.Code != http.StatusOK {
+		t.Errorf("Expected a small body to be accepted, got %d", w.Code)
+	}
+
+	// Test larger bodies are rejected up front, or when read if their length is unknown
+	large := strings.Repeat("a", 32)
+	w := post(strings.NewReader(large), "application/json", 32)
+	if w.Code != http.StatusRequestEntityTooLarge || !strings.Contains(w.Body.String(), string(problem.BodyTooLarge)) {
+		t.Errorf("Expected a body_too_large problem, got %d %s", w.Code, w.Body.String())
+	}
+	if w := post(strings.NewReader(large), "application/json", -1); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected reading a large body to fail, got %d", w.Code)
+	}
+
+	// Test multipart uploads have the larger limit, whatever their length claims
+	upload := func(size int, length int64) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		part, _ := mw.CreateFormFile("file", "word.mp3")
+		part.Write([]byte(strings.Repeat("a", size)))
+		mw.Close()
+		if length == 0 {
+			length = int64(buf.Len())
+		}
+		return post(&buf, mw.FormDataContentType(), length)
+	}
+	if w := upload(32, 0); w.Code != http.StatusOK {
+		t.Errorf("Expected the upload to be accepted, got %d", w.Code)
+	}
+	if w := upload(1024, 0); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected a large upload to be rejected, got %d", w.Code)
+	}
+	if w := upload(1024, -1); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected reading a large upload to fail, got %d", w.Code)
+	}
+}
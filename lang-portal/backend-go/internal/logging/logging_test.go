@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/requestid"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew(t *testing.T) {
+	// Setup
+	var buf bytes.Buffer
+	logger := New(&buf, "json", "warn")
+	ctx := requestid.NewContext(context.Background(), "req-1")
+
+	// Test records below the level are dropped
+	logger.InfoContext(ctx, "Ignored")
+	if buf.Len() != 0 {
+		t.Errorf("Expected info records to be dropped, got %q", buf.String())
+	}
+
+	// Test JSON records carry the request ID, also through derived loggers
+	logger.With("component", "store").ErrorContext(ctx, "Failed to fetch words", "err", "disk I/O error")
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected a JSON record, got %q", buf.String())
+	}
+	if record["msg"] != "Failed to fetch words" || record["request_id"] != "req-1" || record["component"] != "store" || record["err"] != "disk I/O error" {
+		t.Errorf("Unexpected record %v", record)
+	}
+
+	// Test text records
+	buf.Reset()
+	New(&buf, "text", "info").InfoContext(ctx, "Started")
+	if got := buf.String(); !strings.Contains(got, "msg=Started") || !strings.Contains(got, "request_id=req-1") {
+		t.Errorf("Unexpected text record %q", got)
+	}
+
+	// Test records within a trace carry its ID
+	buf.Reset()
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+	New(&buf, "text", "info").InfoContext(ctx, "Traced")
+	if got := buf.String(); !strings.Contains(got, "trace_id=4bf92f3577b34da6a3ce929d0e0e4736") {
+		t.Errorf("Expected the trace ID, got %q", got)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	r := gin.New()
+	r.Use(requestid.Middleware())
+	r.Use(Middleware(New(&buf, "json", "info")))
+	r.GET("/words/:id", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+
+	// Test
+	req := httptest.NewRequest(http.MethodGet, "/words/7", nil)
+	req.Header.Set(requestid.Header, "client-123")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected a JSON record, got %q", buf.String())
+	}
+	if record["level"] != "WARN" || record["method"] != "GET" || record["path"] != "/words/7" || record["status"] != float64(404) {
+		t.Errorf("Unexpected record %v", record)
+	}
+	if record["request_id"] != "client-123" {
+		t.Errorf("Expected the request ID, got %v", record["request_id"])
+	}
+	if _, ok := record["latency"]; !ok {
+		t.Errorf("Expected the latency, got %v", record)
+	}
+}
@@ -0,0 +1,197 @@
+package media
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// MaxImageSize is the largest image accepted for upload
+	MaxImageSize = 5 << 20
+	// MaxImagePixels guards against decompression bombs
+	MaxImagePixels = 40_000_000
+	// ThumbnailSize is the longest edge of a generated thumbnail
+	ThumbnailSize = 200
+)
+
+// ErrInvalidImage is returned when an upload cannot be decoded as an image
+var ErrInvalidImage = errors.New("invalid image")
+
+// imageTypes maps sniffed MIME types to the extension stored
+var imageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// Image describes a stored image and its thumbnail
+type Image struct {
+	SHA256            string
+	Filename          string
+	ThumbnailFilename string
+	ContentType       string
+	Width             int
+	Height            int
+	Size              int64
+}
+
+// ContentHash returns the hex SHA-256 of data, used to de-duplicate images
+func ContentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// SaveImage validates an image, generates its thumbnail and stores both under
+// a content-addressed name, so saving the same bytes twice is a no-op
+func (d Dir) SaveImage(data []byte) (*Image, error) {
+	if len(data) > MaxImageSize {
+		return nil, ErrTooLarge
+	}
+
+	contentType := strings.Split(http.DetectContentType(data), ";")[0]
+	ext, ok := imageTypes[contentType]
+	if !ok {
+		return nil, ErrUnsupportedType
+	}
+
+	// Check dimensions before decoding the full image
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return nil, ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	hash := ContentHash(data)
+	img := &Image{
+		SHA256:            hash,
+		Filename:          filepath.ToSlash(filepath.Join("images", hash[:2], hash+ext)),
+		ThumbnailFilename: filepath.ToSlash(filepath.Join("images", hash[:2], hash+"_thumb"+thumbnailExt(contentType))),
+		ContentType:       contentType,
+		Width:             config.Width,
+		Height:            config.Height,
+		Size:              int64(len(data)),
+	}
+
+	if err := d.writeOnce(img.Filename, data); err != nil {
+		return nil, err
+	}
+
+	thumb, err := encodeThumbnail(Thumbnail(src, ThumbnailSize), contentType)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.writeOnce(img.ThumbnailFilename, thumb); err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+// Stored reports whether an image and its thumbnail are both on disk, as
+// they may have been removed with an orphaned image since they were saved
+func (d Dir) Stored(img *Image) bool {
+	for _, filename := range []string{img.Filename, img.ThumbnailFilename} {
+		if _, err := os.Stat(d.path(filename)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ThumbnailContentType returns the content type thumbnails are encoded as
+func ThumbnailContentType(contentType string) string {
+	if contentType == "image/jpeg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// Thumbnail scales src down so its longest edge is at most size pixels,
+// averaging the premultiplied source pixels covered by each destination pixel
+func Thumbnail(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	dstW, dstH := srcW, srcH
+	if srcW > size || srcH > size {
+		if srcW >= srcH {
+			dstW, dstH = size, max(1, srcH*size/srcW)
+		} else {
+			dstW, dstH = max(1, srcW*size/srcH), size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0 := bounds.Min.Y + y*srcH/dstH
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcH/dstH)
+		for x := 0; x < dstW; x++ {
+			x0 := bounds.Min.X + x*srcW/dstW
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcW/dstW)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
+func thumbnailExt(contentType string) string {
+	if ThumbnailContentType(contentType) == "image/jpeg" {
+		return ".jpg"
+	}
+	return ".png"
+}
+
+func encodeThumbnail(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if ThumbnailContentType(contentType) == "image/jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	return buf.Bytes(), err
+}
+
+// writeOnce stores data under filename unless a file with that name exists
+func (d Dir) writeOnce(filename string, data []byte) error {
+	if _, err := os.Stat(d.path(filename)); err == nil {
+		return nil
+	}
+	_, err := d.write(filename, bytes.NewReader(data), int64(len(data)))
+	return err
+}
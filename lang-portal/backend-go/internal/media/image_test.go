@@ -0,0 +1,111 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("Failed to encode test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		width, height int
+		expectedW     int
+		expectedH     int
+	}{
+		{400, 300, 200, 150},
+		{300, 600, 100, 200},
+		{100, 50, 100, 50},
+		{1000, 1, 200, 1},
+	}
+
+	for _, tt := range tests {
+		src := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+		bounds := Thumbnail(src, 200).Bounds()
+		if bounds.Dx() != tt.expectedW || bounds.Dy() != tt.expectedH {
+			t.Errorf("Thumbnail of %dx%d: expected %dx%d, got %dx%d",
+				tt.width, tt.height, tt.expectedW, tt.expectedH, bounds.Dx(), bounds.Dy())
+		}
+	}
+
+	// Colors are preserved when averaging
+	src := image.NewRGBA(image.Rect(0, 0, 400, 400))
+	for y := 0; y < 400; y++ {
+		for x := 0; x < 400; x++ {
+			src.Set(x, y, color.RGBA{B: 255, A: 255})
+		}
+	}
+	r, g, b, a := Thumbnail(src, 200).At(100, 100).RGBA()
+	if r != 0 || g != 0 || b != 0xffff || a != 0xffff {
+		t.Errorf("Expected opaque blue, got %d %d %d %d", r, g, b, a)
+	}
+}
+
+func TestSaveImage(t *testing.T) {
+	d := Dir(t.TempDir())
+	data := testPNG(t, 400, 300)
+	img, err := d.SaveImage(data)
+	if err != nil {
+		t.Fatalf("SaveImage returned error: %v", err)
+	}
+	if img.ContentType != "image/png" || img.Width != 400 || img.Height != 300 {
+		t.Errorf("Unexpected image metadata: %+v", img)
+	}
+	if img.SHA256 != ContentHash(data) {
+		t.Errorf("Expected hash %s, got %s", ContentHash(data), img.SHA256)
+	}
+
+	// Thumbnail is written and decodable
+	f, err := d.Open(img.ThumbnailFilename)
+	if err != nil {
+		t.Fatalf("Failed to open thumbnail: %v", err)
+	}
+	defer f.Close()
+	config, err := png.DecodeConfig(f)
+	if err != nil {
+		t.Fatalf("Failed to decode thumbnail: %v", err)
+	}
+	if config.Width != 200 || config.Height != 150 {
+		t.Errorf("Expected 200x150 thumbnail, got %dx%d", config.Width, config.Height)
+	}
+
+	// Saving identical content reuses the same files
+	again, err := d.SaveImage(data)
+	if err != nil {
+		t.Fatalf("SaveImage returned error: %v", err)
+	}
+	if again.Filename != img.Filename {
+		t.Errorf("Expected identical content to share filename, got %s and %s", img.Filename, again.Filename)
+	}
+
+	// Invalid content is rejected
+	if _, err := d.SaveImage([]byte("not an image")); err != ErrUnsupportedType {
+		t.Errorf("Expected ErrUnsupportedType, got %v", err)
+	}
+	if _, err := d.SaveImage(data[:100]); err != ErrInvalidImage {
+		t.Errorf("Expected ErrInvalidImage for truncated image, got %v", err)
+	}
+
+	if !d.Stored(img) {
+		t.Error("Expected image files to exist")
+	}
+	if err := d.Remove(img.Filename); err != nil || d.Stored(img) {
+		t.Errorf("Expected the removed image not to be stored, got %v", err)
+	}
+}
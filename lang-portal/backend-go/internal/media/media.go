@@ -0,0 +1,136 @@
+package media
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// MaxAudioSize is the largest audio clip accepted for upload
+const MaxAudioSize = 10 << 20
+
+var (
+	// ErrTooLarge is returned when an upload exceeds the size limit
+	ErrTooLarge = errors.New("file exceeds the maximum upload size")
+	// ErrUnsupportedType is returned when the sniffed MIME type is not allowed
+	ErrUnsupportedType = errors.New("unsupported file type")
+)
+
+// audioTypes maps sniffed MIME types to the content type and extension stored
+var audioTypes = map[string]struct {
+	contentType string
+	ext         string
+}{
+	"audio/mpeg":      {"audio/mpeg", ".mp3"},
+	"audio/wave":      {"audio/wav", ".wav"},
+	"audio/aiff":      {"audio/aiff", ".aiff"},
+	"audio/basic":     {"audio/basic", ".au"},
+	"application/ogg": {"audio/ogg", ".ogg"},
+	"video/webm":      {"audio/webm", ".webm"},
+	"video/mp4":       {"audio/mp4", ".m4a"},
+}
+
+// Dir is the directory media files are stored under. Stored filenames are
+// slash-separated and relative to it.
+type Dir string
+
+// File describes a stored media file
+type File struct {
+	Filename    string
+	ContentType string
+	Size        int64
+}
+
+// SaveAudio sniffs, size-checks and stores an audio clip for a word
+func (d Dir) SaveAudio(wordID int, r io.Reader) (*File, error) {
+	// Sniff the MIME type from the first 512 bytes
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	head = head[:n]
+
+	sniffed := strings.Split(http.DetectContentType(head), ";")[0]
+	audioType, ok := audioTypes[sniffed]
+	if !ok {
+		return nil, ErrUnsupportedType
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return nil, err
+	}
+	filename := filepath.Join("audio", strconv.Itoa(wordID), name+audioType.ext)
+
+	size, err := d.write(filename, io.MultiReader(bytes.NewReader(head), r), MaxAudioSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{
+		Filename:    filepath.ToSlash(filename),
+		ContentType: audioType.contentType,
+		Size:        size,
+	}, nil
+}
+
+// Open opens a stored media file for reading
+func (d Dir) Open(filename string) (*os.File, error) {
+	return os.Open(d.path(filename))
+}
+
+// Remove deletes a stored media file, ignoring files that are already gone
+func (d Dir) Remove(filename string) error {
+	if err := os.Remove(d.path(filename)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// write copies r into filename under the media directory, enforcing limit
+func (d Dir) write(filename string, r io.Reader, limit int64) (int64, error) {
+	full := d.path(filename)
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create media directory: %v", err)
+	}
+
+	f, err := os.Create(full)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := io.Copy(f, io.LimitReader(r, limit+1))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && size > limit {
+		err = ErrTooLarge
+	}
+	if err != nil {
+		os.Remove(full)
+		return 0, err
+	}
+	return size, nil
+}
+
+// path resolves a stored filename against the media directory
+func (d Dir) path(filename string) string {
+	return filepath.Join(string(d), filepath.FromSlash(filename))
+}
+
+func randomName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,152 @@
+// Package metrics collects Prometheus metrics on HTTP requests, database
+// pools and queries, and study activity, and serves them for scraping
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes the name of every metric defined here
+const namespace = "langportal"
+
+// unmatchedRoute labels requests no route matched, so unknown paths do not
+// each get a series of their own
+const unmatchedRoute = "unmatched"
+
+var (
+	registry = prometheus.NewRegistry()
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled, by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time taken to handle HTTP requests, by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "store_query_duration_seconds",
+		Help:      "Time taken by store methods, by method.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"method"})
+
+	reviews = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reviews_recorded_total",
+		Help:      "Word reviews recorded, by result.",
+	}, []string{"result"})
+
+	sessionsStarted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "study_sessions_started_total",
+		Help:      "Study sessions started.",
+	})
+
+	// reviewCount and correctCount back the correct ratio gauge
+	reviewCount, correctCount atomic.Int64
+
+	correctRatio = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "reviews_correct_ratio",
+		Help:      "Share of the reviews recorded by this instance that were correct.",
+	}, func() float64 {
+		total := reviewCount.Load()
+		if total == 0 {
+			return 0
+		}
+		return float64(correctCount.Load()) / float64(total)
+	})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		queryDuration,
+		reviews,
+		sessionsStarted,
+		correctRatio,
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Middleware counts and times each request by its route pattern, such as
+// /api/words/:id, rather than its path
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+		httpRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+var (
+	poolsMu sync.Mutex
+	pools   = map[string]prometheus.Collector{}
+)
+
+// RegisterDB reports the connection pool statistics of db under the given
+// name. Registering a name again replaces the pool reported for it.
+func RegisterDB(name string, db *sql.DB) {
+	poolsMu.Lock()
+	defer poolsMu.Unlock()
+	if previous, ok := pools[name]; ok {
+		registry.Unregister(previous)
+	}
+	collector := collectors.NewDBStatsCollector(db, name)
+	registry.MustRegister(collector)
+	pools[name] = collector
+}
+
+// ObserveQuery records the time taken by a store method that started at
+// start. It is meant to be deferred:
+//
+//	defer metrics.ObserveQuery("ListWords", time.Now())
+func ObserveQuery(method string, start time.Time) {
+	queryDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// ReviewRecorded counts a recorded word review
+func ReviewRecorded(correct bool) {
+	result := "incorrect"
+	if correct {
+		result = "correct"
+		correctCount.Add(1)
+	}
+	reviewCount.Add(1)
+	reviews.WithLabelValues(result).Inc()
+}
+
+// SessionStarted counts a started study session
+func SessionStarted() {
+	sessionsStarted.Inc()
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// scrape returns the metrics text served by Handler
+func scrape(t *testing.T) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	body, _ := io.ReadAll(w.Body)
+	return string(body)
+}
+
+// assertContains checks that the scraped metrics include every line
+func assertContains(t *testing.T, metrics string, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if !strings.Contains(metrics, line) {
+			t.Errorf("Expected metrics to include %s", line)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/test/words/:id", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	// Test requests are labelled by route, not path
+	for _, path := range []string{"/test/words/1", "/test/words/2", "/test/nowhere"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	assertContains(t, scrape(t),
+		`langportal_http_requests_total{method="GET",route="/test/words/:id",status="204"} 2`,
+		`langportal_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`langportal_http_request_duration_seconds_count{method="GET",route="/test/words/:id"} 2`,
+	)
+}
+
+func TestDomainCounters(t *testing.T) {
+	// Test
+	ReviewRecorded(true)
+	ReviewRecorded(true)
+	ReviewRecorded(true)
+	ReviewRecorded(false)
+	SessionStarted()
+
+	assertContains(t, scrape(t),
+		`langportal_reviews_recorded_total{result="correct"} 3`,
+		`langportal_reviews_recorded_total{result="incorrect"} 1`,
+		`langportal_reviews_correct_ratio 0.75`,
+		`langportal_study_sessions_started_total 1`,
+	)
+}
+
+func TestRegisterDB(t *testing.T) {
+	// Setup
+	open := func() *sql.DB {
+		conn, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatalf("Failed to open database: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+
+	// Test pool stats are reported, and registering a name again replaces its pool
+	RegisterDB("test", open())
+	RegisterDB("test", open())
+	assertContains(t, scrape(t), `go_sql_max_open_connections{db_name="test"} 0`)
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Backup describes a database backup file
+type Backup struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+	URL       string    `json:"url"`
+}
+
+// BackupURL returns the URL a backup is downloaded from
+func BackupURL(name string) string {
+	return "/api/system/backups/" + name
+}
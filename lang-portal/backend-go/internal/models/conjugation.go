@@ -0,0 +1,27 @@
+package models
+
+import "github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/conjugation"
+
+type WordConjugations struct {
+	WordID       int               `json:"word_id"`
+	Infinitive   string            `json:"infinitive"`
+	English      string            `json:"english"`
+	Irregular    bool              `json:"irregular"`
+	Conjugations conjugation.Table `json:"conjugations"`
+}
+
+type ConjugationDrill struct {
+	WordID     int                `json:"word_id"`
+	Infinitive string             `json:"infinitive"`
+	English    string             `json:"english"`
+	Tense      conjugation.Tense  `json:"tense"`
+	Person     conjugation.Person `json:"person"`
+}
+
+type ConjugationDrillResult struct {
+	WordReviewResult
+	Tense    conjugation.Tense  `json:"tense"`
+	Person   conjugation.Person `json:"person"`
+	Answer   string             `json:"answer"`
+	Expected string             `json:"expected"`
+}
@@ -0,0 +1,15 @@
+package models
+
+type StudyProgress struct {
+	TotalWords     int     `json:"total_words"`
+	WordsStudied   int     `json:"words_studied"`
+	AverageMastery float64 `json:"average_mastery"`
+}
+
+type QuickStats struct {
+	TotalSessions  int     `json:"total_sessions"`
+	TotalReviews   int     `json:"total_reviews"`
+	TotalWords     int     `json:"total_words"`
+	WordsStudied   int     `json:"words_studied"`
+	AverageMastery float64 `json:"average_mastery"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// ExportedWord is a word with the names of its groups and tags, as written to
+// and read from export files
+type ExportedWord struct {
+	English string   `json:"english"`
+	Spanish string   `json:"spanish"`
+	Level   string   `json:"level"`
+	Groups  []string `json:"groups,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// Export is the contents of an export file
+type Export struct {
+	Words []ExportedWord `json:"words"`
+}
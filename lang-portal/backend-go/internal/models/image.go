@@ -0,0 +1,26 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type Image struct {
+	ID                int       `json:"id" db:"id"`
+	SHA256            string    `json:"sha256" db:"sha256"`
+	Filename          string    `json:"-" db:"filename"`
+	ThumbnailFilename string    `json:"-" db:"thumbnail_filename"`
+	ContentType       string    `json:"content_type" db:"content_type"`
+	Width             int       `json:"width" db:"width"`
+	Height            int       `json:"height" db:"height"`
+	Size              int64     `json:"size" db:"size"`
+	URL               string    `json:"url"`
+	ThumbnailURL      string    `json:"thumbnail_url"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+// ImageURLs returns the URLs an image and its thumbnail are served from
+func ImageURLs(imageID int) (string, string) {
+	url := fmt.Sprintf("/api/images/%d", imageID)
+	return url, url + "/thumbnail"
+}
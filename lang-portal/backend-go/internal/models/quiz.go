@@ -0,0 +1,16 @@
+package models
+
+import "github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/quiz"
+
+type Quiz struct {
+	SessionID int               `json:"session_id"`
+	Type      quiz.QuestionType `json:"type"`
+	Questions []quiz.Question   `json:"questions"`
+}
+
+type QuizAnswerResult struct {
+	WordReviewResult
+	Type     quiz.QuestionType `json:"type"`
+	Answer   string            `json:"answer"`
+	Expected string            `json:"expected"`
+}
@@ -12,5 +12,6 @@ type StudyActivity struct {
 
 type StudyActivityWithStats struct {
 	StudyActivity
-	TotalSessions int `json:"total_sessions"`
+	TotalSessions int   `json:"total_sessions"`
+	GroupIDs      []int `json:"group_ids"`
 }
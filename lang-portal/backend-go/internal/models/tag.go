@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type Tag struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type TagWithStats struct {
+	Tag
+	WordCount int `json:"word_count"`
+}
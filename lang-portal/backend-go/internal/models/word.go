@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type Word struct {
 	ID        int       `json:"id" db:"id"`
@@ -13,7 +16,36 @@ type Word struct {
 
 type WordWithStats struct {
 	Word
-	CorrectCount   int     `json:"correct_count"`
-	IncorrectCount int     `json:"incorrect_count"`
-	MasteryLevel   float64 `json:"mastery_level"`
+	CorrectCount   int         `json:"correct_count"`
+	IncorrectCount int         `json:"incorrect_count"`
+	MasteryLevel   float64     `json:"mastery_level"`
+	Images         []WordImage `json:"images"`
+	Tags           []string    `json:"tags"`
+	SortKey        interface{} `json:"-"`
+}
+
+type WordImage struct {
+	ID           int    `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+type WordAudio struct {
+	ID          int       `json:"id" db:"id"`
+	WordID      int       `json:"word_id" db:"word_id"`
+	Filename    string    `json:"-" db:"filename"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Size        int64     `json:"size" db:"size"`
+	URL         string    `json:"url"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+type WordDetail struct {
+	WordWithStats
+	Audio []WordAudio `json:"audio"`
+}
+
+// AudioURL returns the URL a pronunciation clip is served from
+func AudioURL(wordID, audioID int) string {
+	return fmt.Sprintf("/api/words/%d/audio/%d", wordID, audioID)
 }
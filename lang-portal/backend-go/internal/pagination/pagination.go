@@ -0,0 +1,159 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultLimit is the page size used when no limit is requested
+	DefaultLimit = 20
+	// MaxLimit is the largest page size a client may request
+	MaxLimit = 100
+)
+
+var (
+	// ErrInvalidLimit is returned when the limit parameter is not a positive integer
+	ErrInvalidLimit = errors.New("limit must be a positive integer")
+	// ErrInvalidCursor is returned when a cursor is malformed or was issued for another ordering
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// cursor is the decoded form of the opaque cursor handed to clients. It holds
+// the ordering it was issued for and the sort key values of the last item seen.
+type cursor struct {
+	Order  string        `json:"o"`
+	Values []interface{} `json:"v"`
+}
+
+// Request describes the page a client asked for
+type Request struct {
+	Limit int
+	order string
+	after []interface{}
+}
+
+// Info describes the returned page
+type Info struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// Page is the envelope every list endpoint responds with
+type Page[T any] struct {
+	Items      []T  `json:"items"`
+	Pagination Info `json:"pagination"`
+}
+
+// Parse reads the limit and cursor query parameters. order names the sort
+// order of the list, such as "english:asc", so that a cursor issued for one
+// ordering cannot be replayed against another; keys is the number of sort key
+// values each cursor carries.
+func Parse(c *gin.Context, order string, keys int) (Request, error) {
+	r := Request{Limit: DefaultLimit, order: order}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return r, ErrInvalidLimit
+		}
+		r.Limit = min(limit, MaxLimit)
+	}
+
+	if value := c.Query("cursor"); value != "" {
+		data, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			return r, ErrInvalidCursor
+		}
+		var decoded cursor
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return r, ErrInvalidCursor
+		}
+		if decoded.Order != order || len(decoded.Values) != keys {
+			return r, ErrInvalidCursor
+		}
+		for _, v := range decoded.Values {
+			switch v.(type) {
+			case string, float64:
+			default:
+				return r, ErrInvalidCursor
+			}
+		}
+		r.after = decoded.Values
+	}
+
+	return r, nil
+}
+
+// Fetch is the number of rows to query; one more than the limit so the
+// handler can tell whether another page follows
+func (r Request) Fetch() int {
+	return r.Limit + 1
+}
+
+// Keyset returns a condition selecting the rows that sort after the cursor,
+// given the sort key columns (ending with a unique column) and the direction
+// they are ordered in. Without a cursor the condition matches every row.
+func (r Request) Keyset(columns []string, desc bool) (string, []interface{}) {
+	if r.after == nil {
+		return "1 = 1", nil
+	}
+
+	operator := ">"
+	if desc {
+		operator = "<"
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	condition := fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), operator, placeholders)
+	return condition, append([]interface{}{}, r.after...)
+}
+
+// Respond trims the rows fetched with Fetch to the page size, sets Link
+// headers and writes the page envelope. key returns the sort key values of
+// an item, in the order passed to Keyset.
+func Respond[T any](c *gin.Context, r Request, items []T, key func(T) []interface{}) {
+	page := Page[T]{
+		Items:      items,
+		Pagination: Info{Limit: r.Limit},
+	}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+
+	if len(page.Items) > r.Limit {
+		page.Items = page.Items[:r.Limit]
+		data, _ := json.Marshal(cursor{Order: r.order, Values: key(page.Items[r.Limit-1])})
+		page.Pagination.NextCursor = base64.RawURLEncoding.EncodeToString(data)
+		page.Pagination.HasMore = true
+	}
+
+	links := []string{link(c, r.Limit, "", "first")}
+	if page.Pagination.HasMore {
+		links = append(links, link(c, r.Limit, page.Pagination.NextCursor, "next"))
+	}
+	c.Header("Link", strings.Join(links, ", "))
+
+	c.JSON(http.StatusOK, page)
+}
+
+// link builds a Link header entry for the current URL at the given cursor
+func link(c *gin.Context, limit int, cursor, rel string) string {
+	u := *c.Request.URL
+	query := u.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	if cursor == "" {
+		query.Del("cursor")
+	} else {
+		query.Set("cursor", cursor)
+	}
+	u.RawQuery = query.Encode()
+	return fmt.Sprintf(`<%s>; rel="%s"`, u.RequestURI(), rel)
+}
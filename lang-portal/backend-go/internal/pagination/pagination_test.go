@@ -0,0 +1,112 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type item struct {
+	Name string `json:"name"`
+	ID   int    `json:"id"`
+}
+
+func itemKey(i item) []interface{} {
+	return []interface{}{i.Name, i.ID}
+}
+
+// newContext returns a gin context for a GET request to target
+func newContext(target string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return c, w
+}
+
+func TestParse(t *testing.T) {
+	c, _ := newContext("/items")
+	r, err := Parse(c, "name:asc", 2)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if r.Limit != DefaultLimit || r.Fetch() != DefaultLimit+1 {
+		t.Errorf("Expected default limit %d, got %d", DefaultLimit, r.Limit)
+	}
+	if condition, args := r.Keyset([]string{"name", "id"}, false); condition != "1 = 1" || args != nil {
+		t.Errorf("Expected no keyset condition without a cursor, got %q %v", condition, args)
+	}
+
+	c, _ = newContext("/items?limit=1000")
+	r, err = Parse(c, "name:asc", 2)
+	if err != nil || r.Limit != MaxLimit {
+		t.Errorf("Expected limit clamped to %d, got %d (%v)", MaxLimit, r.Limit, err)
+	}
+
+	for _, target := range []string{"/items?limit=0", "/items?limit=-1", "/items?limit=ten"} {
+		c, _ = newContext(target)
+		if _, err := Parse(c, "name:asc", 2); err != ErrInvalidLimit {
+			t.Errorf("%s: expected ErrInvalidLimit, got %v", target, err)
+		}
+	}
+
+	for _, target := range []string{"/items?cursor=***", "/items?cursor=bm90LWpzb24", "/items?cursor=e30"} {
+		c, _ = newContext(target)
+		if _, err := Parse(c, "name:asc", 2); err != ErrInvalidCursor {
+			t.Errorf("%s: expected ErrInvalidCursor, got %v", target, err)
+		}
+	}
+}
+
+func TestRespond(t *testing.T) {
+	items := []item{{"a", 1}, {"b", 2}, {"c", 3}}
+
+	// First page
+	c, w := newContext("/items?limit=2&sort=name")
+	r, err := Parse(c, "name:asc", 2)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	Respond(c, r, items, itemKey)
+
+	body := w.Body.String()
+	if !strings.Contains(body, `"items":[{"name":"a","id":1},{"name":"b","id":2}]`) || !strings.Contains(body, `"has_more":true`) {
+		t.Errorf("Unexpected first page: %s", body)
+	}
+	link := w.Header().Get("Link")
+	if !strings.Contains(link, `rel="first"`) || !strings.Contains(link, `rel="next"`) || !strings.Contains(link, "sort=name") {
+		t.Errorf("Unexpected Link header: %s", link)
+	}
+
+	// Follow the next link
+	next := link[strings.LastIndex(link, "<")+1 : strings.LastIndex(link, ">")]
+	c, w = newContext(next)
+	r, err = Parse(c, "name:asc", 2)
+	if err != nil {
+		t.Fatalf("Parse returned error for next link: %v", err)
+	}
+	condition, args := r.Keyset([]string{"name", "id"}, true)
+	if condition != "(name, id) < (?, ?)" || len(args) != 2 || args[0] != "b" || args[1] != float64(2) {
+		t.Errorf("Unexpected keyset condition: %q %v", condition, args)
+	}
+
+	// A cursor cannot be replayed against another ordering
+	c, _ = newContext(next)
+	if _, err := Parse(c, "name:desc", 2); err != ErrInvalidCursor {
+		t.Errorf("Expected ErrInvalidCursor for a different ordering, got %v", err)
+	}
+
+	// Last page
+	c, w = newContext("/items")
+	r, _ = Parse(c, "name:asc", 2)
+	Respond(c, r, []item(nil), itemKey)
+	if body := w.Body.String(); body != `{"items":[],"pagination":{"limit":20,"has_more":false}}` {
+		t.Errorf("Unexpected empty page: %s", body)
+	}
+	if strings.Contains(w.Header().Get("Link"), `rel="next"`) {
+		t.Error("Expected no next link on the last page")
+	}
+}
@@ -0,0 +1,137 @@
+// Package problem writes error responses as RFC 7807 problem details, each
+// identified by a stable code clients can branch on
+package problem
+
+import (
+	"net/http"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType is the media type of problem responses
+const ContentType = "application/problem+json"
+
+// typePrefix turns a code into the problem's type URI
+const typePrefix = "urn:langportal:problem:"
+
+// Code identifies a kind of problem. Codes are part of the API and must not
+// change once published.
+type Code string
+
+const (
+	InvalidParameter      Code = "invalid_parameter"
+	InvalidBody           Code = "invalid_body"
+	ValidationFailed      Code = "validation_failed"
+	WordNotVerb           Code = "word_not_verb"
+	WordNotFound          Code = "word_not_found"
+	WordNotInGroup        Code = "word_not_in_group"
+	GroupNotFound         Code = "group_not_found"
+	StudyActivityNotFound Code = "study_activity_not_found"
+	StudySessionNotFound  Code = "study_session_not_found"
+	TagNotFound           Code = "tag_not_found"
+	ImageNotFound         Code = "image_not_found"
+	AudioNotFound         Code = "audio_not_found"
+	BackupNotFound        Code = "backup_not_found"
+	TagExists             Code = "tag_exists"
+	BackupIncompatible    Code = "backup_incompatible"
+	FileTooLarge          Code = "file_too_large"
+	BodyTooLarge          Code = "body_too_large"
+	RateLimited           Code = "rate_limited"
+	UnsupportedMediaType  Code = "unsupported_media_type"
+	Internal              Code = "internal_error"
+	BackupsUnsupported    Code = "backups_unsupported"
+)
+
+// definition is the status and title shared by every problem with a code
+type definition struct {
+	status int
+	title  string
+}
+
+var definitions = map[Code]definition{
+	InvalidParameter:      {http.StatusBadRequest, "Invalid parameter"},
+	InvalidBody:           {http.StatusBadRequest, "Invalid request body"},
+	ValidationFailed:      {http.StatusUnprocessableEntity, "Request validation failed"},
+	WordNotVerb:           {http.StatusBadRequest, "Word is not a verb"},
+	WordNotFound:          {http.StatusNotFound, "Word not found"},
+	WordNotInGroup:        {http.StatusNotFound, "Word does not belong to the group"},
+	GroupNotFound:         {http.StatusNotFound, "Group not found"},
+	StudyActivityNotFound: {http.StatusNotFound, "Study activity not found"},
+	StudySessionNotFound:  {http.StatusNotFound, "Study session not found"},
+	TagNotFound:           {http.StatusNotFound, "Tag not found"},
+	ImageNotFound:         {http.StatusNotFound, "Image not found"},
+	AudioNotFound:         {http.StatusNotFound, "Audio not found"},
+	BackupNotFound:        {http.StatusNotFound, "Backup not found"},
+	TagExists:             {http.StatusConflict, "Tag already exists"},
+	BackupIncompatible:    {http.StatusConflict, "Backup schema version does not match the database"},
+	FileTooLarge:          {http.StatusRequestEntityTooLarge, "File is too large"},
+	BodyTooLarge:          {http.StatusRequestEntityTooLarge, "Request body is too large"},
+	RateLimited:           {http.StatusTooManyRequests, "Too many requests"},
+	UnsupportedMediaType:  {http.StatusUnsupportedMediaType, "Unsupported media type"},
+	Internal:              {http.StatusInternalServerError, "Internal server error"},
+	BackupsUnsupported:    {http.StatusNotImplemented, "Backups are only supported for SQLite databases"},
+}
+
+// Codes lists every problem code
+func Codes() []Code {
+	codes := make([]Code, 0, len(definitions))
+	for code := range definitions {
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// FieldError describes why one request field or parameter was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Field returns a FieldError
+func Field(field, message string) FieldError {
+	return FieldError{Field: field, Message: message}
+}
+
+// Problem is an RFC 7807 problem details object. Code, RequestID and Errors
+// are extension members.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      Code         `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// New returns a problem with the given code, detail and invalid fields
+func New(code Code, detail string, fields ...FieldError) *Problem {
+	d, ok := definitions[code]
+	if !ok {
+		code, d = Internal, definitions[Internal]
+	}
+	return &Problem{
+		Type:   typePrefix + string(code),
+		Title:  d.title,
+		Status: d.status,
+		Detail: detail,
+		Code:   code,
+		Errors: fields,
+	}
+}
+
+// Write sends p as the response and aborts the handler chain. The request
+// path and ID are filled in from the context.
+func Write(c *gin.Context, p *Problem) {
+	p.Instance = c.Request.URL.Path
+	p.RequestID = requestid.FromContext(c.Request.Context())
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+// Abort writes a problem with the given code, detail and invalid fields
+func Abort(c *gin.Context, code Code, detail string, fields ...FieldError) {
+	Write(c, New(code, detail, fields...))
+}
@@ -0,0 +1,74 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+func TestAbort(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(requestid.Middleware())
+	r.GET("/api/words/:id", func(c *gin.Context) {
+		Abort(c, InvalidParameter, "Invalid word ID", Field("id", "must be an integer"))
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/words/abc", nil)
+	req.Header.Set(requestid.Header, "req-1")
+	w := httptest.NewRecorder()
+
+	// Test
+	r.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("Expected content type %s, got %s", ContentType, got)
+	}
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("Failed to parse problem: %v", err)
+	}
+	expected := Problem{
+		Type:      "urn:langportal:problem:invalid_parameter",
+		Title:     "Invalid parameter",
+		Status:    http.StatusBadRequest,
+		Detail:    "Invalid word ID",
+		Instance:  "/api/words/abc",
+		Code:      InvalidParameter,
+		RequestID: "req-1",
+		Errors:    []FieldError{{Field: "id", Message: "must be an integer"}},
+	}
+	got, _ := json.Marshal(p)
+	want, _ := json.Marshal(expected)
+	if string(got) != string(want) {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestCodes(t *testing.T) {
+	// Test every code has a distinct type and an error status
+	types := map[string]bool{}
+	for _, code := range Codes() {
+		p := New(code, "")
+		if p.Code != code || p.Title == "" || p.Status < 400 {
+			t.Errorf("Code %s is not defined: %+v", code, p)
+		}
+		if types[p.Type] {
+			t.Errorf("Type %s is used twice", p.Type)
+		}
+		types[p.Type] = true
+	}
+
+	// Test unknown codes become internal errors
+	if p := New("made_up", "detail"); p.Code != Internal || p.Status != http.StatusInternalServerError {
+		t.Errorf("Expected an internal error, got %+v", p)
+	}
+}
@@ -0,0 +1,130 @@
+package quiz
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// QuestionType identifies how a quiz question is asked
+type QuestionType string
+
+const (
+	EnglishToSpanish QuestionType = "english_to_spanish"
+	SpanishToEnglish QuestionType = "spanish_to_english"
+	PictureToWord    QuestionType = "picture_to_word"
+)
+
+// Types lists every supported question type
+var Types = []QuestionType{EnglishToSpanish, SpanishToEnglish, PictureToWord}
+
+// choiceCount is the number of answer choices offered per question
+const choiceCount = 4
+
+// Word is a vocabulary word that questions can be built from
+type Word struct {
+	ID                int
+	English           string
+	Spanish           string
+	ImageURL          string
+	ImageThumbnailURL string
+}
+
+// Question is a multiple choice quiz question about a single word
+type Question struct {
+	WordID       int          `json:"word_id"`
+	Type         QuestionType `json:"type"`
+	Prompt       string       `json:"prompt,omitempty"`
+	ImageURL     string       `json:"image_url,omitempty"`
+	ThumbnailURL string       `json:"thumbnail_url,omitempty"`
+	Choices      []string     `json:"choices"`
+}
+
+// ParseType converts a string into a supported QuestionType
+func ParseType(s string) (QuestionType, bool) {
+	for _, t := range Types {
+		if string(t) == s {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// Build creates up to limit questions of the given type from words in random
+// order. Picture questions are only built for words that have an image.
+func Build(t QuestionType, words []Word, limit int) []Question {
+	candidates := make([]Word, 0, len(words))
+	for _, word := range words {
+		if t == PictureToWord && word.ImageURL == "" {
+			continue
+		}
+		candidates = append(candidates, word)
+	}
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	questions := make([]Question, 0, len(candidates))
+	for _, word := range candidates {
+		question := Question{
+			WordID:  word.ID,
+			Type:    t,
+			Choices: choices(t, word, words),
+		}
+		switch t {
+		case EnglishToSpanish:
+			question.Prompt = word.English
+		case SpanishToEnglish:
+			question.Prompt = word.Spanish
+		case PictureToWord:
+			question.ImageURL = word.ImageURL
+			question.ThumbnailURL = word.ImageThumbnailURL
+		}
+		questions = append(questions, question)
+	}
+	return questions
+}
+
+// Expected returns the correct answer to a question of the given type
+func Expected(t QuestionType, word Word) string {
+	if t == SpanishToEnglish {
+		return word.English
+	}
+	return word.Spanish
+}
+
+// Check compares an answer against the expected one, ignoring case and
+// surrounding whitespace
+func Check(expected, answer string) bool {
+	return strings.EqualFold(strings.TrimSpace(expected), strings.TrimSpace(answer))
+}
+
+// choices returns the correct answer and distinct distractors drawn from the
+// other words, shuffled together
+func choices(t QuestionType, word Word, words []Word) []string {
+	correct := Expected(t, word)
+	seen := map[string]bool{strings.ToLower(correct): true}
+
+	var distractors []string
+	for _, other := range words {
+		answer := Expected(t, other)
+		if !seen[strings.ToLower(answer)] {
+			seen[strings.ToLower(answer)] = true
+			distractors = append(distractors, answer)
+		}
+	}
+	rand.Shuffle(len(distractors), func(i, j int) {
+		distractors[i], distractors[j] = distractors[j], distractors[i]
+	})
+	if len(distractors) > choiceCount-1 {
+		distractors = distractors[:choiceCount-1]
+	}
+
+	result := append(distractors, correct)
+	rand.Shuffle(len(result), func(i, j int) {
+		result[i], result[j] = result[j], result[i]
+	})
+	return result
+}
@@ -0,0 +1,70 @@
+package quiz
+
+import "testing"
+
+var testWords = []Word{
+	{ID: 1, English: "hello", Spanish: "hola", ImageURL: "/api/images/1", ImageThumbnailURL: "/api/images/1/thumbnail"},
+	{ID: 2, English: "goodbye", Spanish: "adiós"},
+	{ID: 3, English: "water", Spanish: "agua"},
+	{ID: 4, English: "food", Spanish: "comida"},
+	{ID: 5, English: "house", Spanish: "casa"},
+}
+
+func TestBuild(t *testing.T) {
+	questions := Build(EnglishToSpanish, testWords, 3)
+	if len(questions) != 3 {
+		t.Fatalf("Expected 3 questions, got %d", len(questions))
+	}
+
+	for _, question := range questions {
+		if len(question.Choices) != choiceCount {
+			t.Errorf("Expected %d choices, got %v", choiceCount, question.Choices)
+		}
+
+		word := testWords[question.WordID-1]
+		if question.Prompt != word.English {
+			t.Errorf("Expected prompt %q, got %q", word.English, question.Prompt)
+		}
+
+		found := false
+		for _, choice := range question.Choices {
+			if choice == word.Spanish {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected correct answer %q among choices %v", word.Spanish, question.Choices)
+		}
+	}
+}
+
+func TestBuildPictureToWord(t *testing.T) {
+	questions := Build(PictureToWord, testWords, 10)
+	if len(questions) != 1 {
+		t.Fatalf("Expected 1 picture question, got %d", len(questions))
+	}
+
+	question := questions[0]
+	if question.WordID != 1 || question.ImageURL != "/api/images/1" || question.Prompt != "" {
+		t.Errorf("Unexpected picture question: %+v", question)
+	}
+	if len(question.Choices) != choiceCount {
+		t.Errorf("Expected %d choices, got %v", choiceCount, question.Choices)
+	}
+}
+
+func TestExpectedAndCheck(t *testing.T) {
+	word := testWords[0]
+	if Expected(PictureToWord, word) != "hola" || Expected(SpanishToEnglish, word) != "hello" {
+		t.Error("Unexpected expected answers")
+	}
+	if !Check("hola", " Hola ") {
+		t.Error("Expected answer to match ignoring case and whitespace")
+	}
+	if _, ok := ParseType("picture_to_word"); !ok {
+		t.Error("Expected picture_to_word to be a valid type")
+	}
+	if _, ok := ParseType("essay"); ok {
+		t.Error("Expected unsupported type to be rejected")
+	}
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+)
+
+// groupColumns are the columns ScanGroup reads
+var groupColumns = []string{
+	"g.id",
+	"g.name",
+	"g.created_at",
+	"g.updated_at",
+	"COUNT(DISTINCT wg.word_id) as total_word_count",
+	"COUNT(DISTINCT CASE WHEN wri.correct THEN w.id END) as mastered_words",
+	"COUNT(DISTINCT CASE WHEN NOT wri.correct THEN w.id END) as in_progress_words",
+	MasteryExpr + " as average_mastery",
+}
+
+// GroupQuery selects word groups with their review statistics
+type GroupQuery struct {
+	where []clause
+	page  *pagination.Request
+}
+
+// Groups starts a query for word groups with their review statistics,
+// ordered by name
+func Groups() *GroupQuery {
+	return &GroupQuery{}
+}
+
+// ID selects a single group
+func (q *GroupQuery) ID(groupID int) *GroupQuery {
+	q.where = append(q.where, clause{"g.id = ?", []interface{}{groupID}})
+	return q
+}
+
+// Page restricts the query to the rows of a page
+func (q *GroupQuery) Page(page pagination.Request) *GroupQuery {
+	q.page = &page
+	return q
+}
+
+// SQL returns the statement and its arguments
+func (q *GroupQuery) SQL() (string, []interface{}) {
+	query := Select(groupColumns...).
+		From("groups g").
+		Join("LEFT JOIN word_groups wg ON g.id = wg.group_id").
+		Join("LEFT JOIN words w ON wg.word_id = w.id").
+		Join("LEFT JOIN word_review_items wri ON w.id = wri.word_id")
+	query.where = append(query.where, q.where...)
+	if q.page != nil {
+		keyset, args := q.page.Keyset([]string{"g.name", "g.id"}, false)
+		query.Where(keyset, args...).Limit(q.page.Fetch())
+	}
+	return query.GroupBy("g.id").OrderBy("g.name, g.id").SQL()
+}
+
+// All runs the query and returns every group it selects
+func (q *GroupQuery) All(ctx context.Context, conn db.Querier) ([]models.GroupWithStats, error) {
+	query, args := q.SQL()
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []models.GroupWithStats
+	for rows.Next() {
+		group, err := ScanGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+// One runs the query and returns the first group it selects, or sql.ErrNoRows
+func (q *GroupQuery) One(ctx context.Context, conn db.Querier) (*models.GroupWithStats, error) {
+	query, args := q.SQL()
+	group, err := ScanGroup(conn.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// ScanGroup reads a row of a GroupQuery
+func ScanGroup(row Scanner) (models.GroupWithStats, error) {
+	var group models.GroupWithStats
+	err := row.Scan(
+		&group.ID,
+		&group.Name,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+		&group.Statistics.TotalWordCount,
+		&group.Statistics.MasteredWords,
+		&group.Statistics.InProgressWords,
+		&group.Statistics.AverageMastery,
+	)
+	return group, err
+}
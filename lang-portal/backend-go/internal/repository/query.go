@@ -0,0 +1,125 @@
+// Package repository builds the SELECT statements shared by the store and
+// maps their rows onto models, so each query and scan is written once.
+package repository
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Scanner is implemented by *sql.Row and *sql.Rows
+type Scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Query is a SELECT statement assembled from fixed SQL fragments. Values are
+// always bound as arguments, never formatted into the SQL.
+type Query struct {
+	columns []string
+	from    string
+	joins   []clause
+	where   []clause
+	groupBy string
+	having  []clause
+	orderBy string
+	limit   int
+}
+
+// clause is a SQL fragment together with the arguments of its placeholders
+type clause struct {
+	sql  string
+	args []interface{}
+}
+
+// Select starts a query selecting the given columns
+func Select(columns ...string) *Query {
+	return &Query{columns: columns}
+}
+
+// From sets the table, or joined tables, the query reads from
+func (q *Query) From(from string) *Query {
+	q.from = from
+	return q
+}
+
+// Join adds a JOIN clause
+func (q *Query) Join(join string, args ...interface{}) *Query {
+	q.joins = append(q.joins, clause{join, args})
+	return q
+}
+
+// Where adds a condition rows must meet; conditions are combined with AND
+func (q *Query) Where(condition string, args ...interface{}) *Query {
+	q.where = append(q.where, clause{condition, args})
+	return q
+}
+
+// GroupBy sets the GROUP BY expression
+func (q *Query) GroupBy(expr string) *Query {
+	q.groupBy = expr
+	return q
+}
+
+// Having adds a condition groups must meet; conditions are combined with AND
+func (q *Query) Having(condition string, args ...interface{}) *Query {
+	q.having = append(q.having, clause{condition, args})
+	return q
+}
+
+// OrderBy sets the ORDER BY expression
+func (q *Query) OrderBy(expr string) *Query {
+	q.orderBy = expr
+	return q
+}
+
+// Limit caps the number of rows returned; zero means no limit
+func (q *Query) Limit(n int) *Query {
+	q.limit = n
+	return q
+}
+
+// SQL returns the statement and its arguments, in placeholder order
+func (q *Query) SQL() (string, []interface{}) {
+	var b strings.Builder
+	var args []interface{}
+
+	b.WriteString("SELECT ")
+	b.WriteString(strings.Join(q.columns, ", "))
+	b.WriteString(" FROM ")
+	b.WriteString(q.from)
+	for _, join := range q.joins {
+		b.WriteString(" ")
+		b.WriteString(join.sql)
+		args = append(args, join.args...)
+	}
+	args = writeConditions(&b, " WHERE ", q.where, args)
+	if q.groupBy != "" {
+		b.WriteString(" GROUP BY ")
+		b.WriteString(q.groupBy)
+	}
+	args = writeConditions(&b, " HAVING ", q.having, args)
+	if q.orderBy != "" {
+		b.WriteString(" ORDER BY ")
+		b.WriteString(q.orderBy)
+	}
+	if q.limit > 0 {
+		b.WriteString(" LIMIT ")
+		b.WriteString(strconv.Itoa(q.limit))
+	}
+	return b.String(), args
+}
+
+// writeConditions writes the conditions joined with AND after keyword and
+// returns args with their arguments appended
+func writeConditions(b *strings.Builder, keyword string, conditions []clause, args []interface{}) []interface{} {
+	for i, condition := range conditions {
+		if i == 0 {
+			b.WriteString(keyword)
+		} else {
+			b.WriteString(" AND ")
+		}
+		b.WriteString(condition.sql)
+		args = append(args, condition.args...)
+	}
+	return args
+}
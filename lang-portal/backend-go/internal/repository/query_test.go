@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuerySQL(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *Query
+		sql   string
+		args  []interface{}
+	}{
+		{
+			name:  "columns only",
+			query: Select("id", "name").From("tags"),
+			sql:   "SELECT id, name FROM tags",
+		},
+		{
+			name: "every clause",
+			query: Select("w.id", "COUNT(wri.id)").
+				From("words w").
+				Join("LEFT JOIN word_review_items wri ON w.id = wri.word_id AND wri.study_activity_id = ?", 1).
+				Where("w.level = ?", "beginner").
+				Where("w.id > ?", 2).
+				GroupBy("w.id").
+				Having("COUNT(wri.id) > ?", 3).
+				OrderBy("w.id").
+				Limit(10),
+			sql: "SELECT w.id, COUNT(wri.id) FROM words w" +
+				" LEFT JOIN word_review_items wri ON w.id = wri.word_id AND wri.study_activity_id = ?" +
+				" WHERE w.level = ? AND w.id > ?" +
+				" GROUP BY w.id HAVING COUNT(wri.id) > ?" +
+				" ORDER BY w.id LIMIT 10",
+			args: []interface{}{1, "beginner", 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.query.SQL()
+			if sql != tt.sql {
+				t.Errorf("Expected SQL %q, got %q", tt.sql, sql)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("Expected args %v, got %v", tt.args, args)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
+)
+
+func TestWords(t *testing.T) {
+	t.Parallel()
+	// Setup
+	conn := db.NewConn(testutil.NewTestDB(t), db.SQLite)
+	ctx := context.Background()
+
+	// Test statistics of every word
+	words, err := Words().All(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to query words: %v", err)
+	}
+	if len(words) != 3 {
+		t.Fatalf("Expected 3 words, got %d", len(words))
+	}
+	if words[0].English != "hello" || words[0].CorrectCount != 1 || words[0].MasteryLevel != 1 {
+		t.Errorf("Expected hello with one correct review, got %+v", words[0])
+	}
+	if words[1].IncorrectCount != 1 || words[1].MasteryLevel != 0 {
+		t.Errorf("Expected goodbye with one incorrect review, got %+v", words[1])
+	}
+
+	// Test group filter and sort key
+	words, err = Words().InGroup(1).SortBy("english", true).All(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to query group words: %v", err)
+	}
+	if len(words) != 2 || words[0].English != "hello" || words[1].English != "goodbye" {
+		t.Errorf("Expected hello then goodbye, got %+v", words)
+	}
+	if words[0].SortKey != "hello" {
+		t.Errorf("Expected sort key 'hello', got %v", words[0].SortKey)
+	}
+
+	// Test statistics scoped to an activity without reviews
+	words, err = Words().InGroup(1).ReviewedIn(2).All(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to query activity words: %v", err)
+	}
+	for _, word := range words {
+		if word.CorrectCount != 0 || word.IncorrectCount != 0 {
+			t.Errorf("Expected no reviews in activity 2 for %s, got %+v", word.English, word)
+		}
+	}
+
+	// Test statistic filters
+	words, err = Words().MinMastery(0.5).Reviewed(true).All(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to query mastered words: %v", err)
+	}
+	if len(words) != 2 || words[0].ID != 1 || words[1].ID != 3 {
+		t.Errorf("Expected words 1 and 3, got %+v", words)
+	}
+
+	// Test page size
+	words, err = Words().Page(pagination.Request{Limit: 1}).All(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to query page: %v", err)
+	}
+	if len(words) != 2 {
+		t.Errorf("Expected limit+1 words, got %d", len(words))
+	}
+
+	// Test single word
+	word, err := Words().ID(3).One(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to query word: %v", err)
+	}
+	if word.Spanish != "gracias" {
+		t.Errorf("Expected gracias, got %s", word.Spanish)
+	}
+	if _, err := Words().ID(99).One(ctx, conn); err != sql.ErrNoRows {
+		t.Errorf("Expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+	// Setup
+	conn := db.NewConn(testutil.NewTestDB(t), db.SQLite)
+	ctx := context.Background()
+
+	// Test most recent first, ties broken by ID
+	sessions, err := Sessions().All(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to query sessions: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != 2 || sessions[1].ID != 1 {
+		t.Fatalf("Expected sessions 2 and 1, got %+v", sessions)
+	}
+
+	// Test statistics, score and end time
+	session := sessions[1]
+	if session.ActivityName != "Flashcards" || session.GroupName != "Test Group 1" {
+		t.Errorf("Expected Flashcards on Test Group 1, got %+v", session)
+	}
+	if session.ReviewItemsCount != 3 || session.CorrectCount != 2 || session.IncorrectCount != 1 {
+		t.Errorf("Expected 3 reviews with 2 correct, got %+v", session)
+	}
+	if session.Score != 66 {
+		t.Errorf("Expected score 66, got %d", session.Score)
+	}
+	if session.EndTime.Sub(session.StartTime).Minutes() != 30 {
+		t.Errorf("Expected a 30 minute session, got %v to %v", session.StartTime, session.EndTime)
+	}
+
+	// Test filters
+	sessions, err = Sessions().ForActivity(2).InGroup(2).All(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to query filtered sessions: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != 2 || sessions[0].ReviewItemsCount != 0 {
+		t.Errorf("Expected session 2 without reviews, got %+v", sessions)
+	}
+
+	// Test single and latest session
+	if _, err := Sessions().ID(1).One(ctx, conn); err != nil {
+		t.Errorf("Failed to query session: %v", err)
+	}
+	if _, err := Sessions().Latest().One(ctx, conn); err != nil {
+		t.Errorf("Failed to query latest session: %v", err)
+	}
+	if _, err := Sessions().ID(99).One(ctx, conn); err != sql.ErrNoRows {
+		t.Errorf("Expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	t.Parallel()
+	// Setup
+	conn := db.NewConn(testutil.NewTestDB(t), db.SQLite)
+	ctx := context.Background()
+
+	// Test every group, ordered by name
+	groups, err := Groups().All(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to query groups: %v", err)
+	}
+	if len(groups) != 2 || groups[0].Name != "Test Group 1" {
+		t.Fatalf("Expected two groups starting with Test Group 1, got %+v", groups)
+	}
+
+	// Test statistics
+	group, err := Groups().ID(1).One(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to query group: %v", err)
+	}
+	stats := group.Statistics
+	if stats.TotalWordCount != 2 || stats.MasteredWords != 1 || stats.InProgressWords != 1 || stats.AverageMastery != 0.5 {
+		t.Errorf("Expected 2 words with one mastered, got %+v", stats)
+	}
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+)
+
+// sessionReviews matches the review items counted towards a study session
+const sessionReviews = "wri.study_activity_id = ss.study_activity_id"
+
+// sessionColumns are the columns ScanSession reads
+var sessionColumns = []string{
+	"ss.id",
+	"sa.name as activity_name",
+	"g.name as group_name",
+	"ss.created_at as start_time",
+	"(SELECT COUNT(*) FROM word_review_items wri WHERE " + sessionReviews + ") as review_items_count",
+	"(SELECT COUNT(*) FROM word_review_items wri WHERE " + sessionReviews + " AND wri.correct) as correct_count",
+	"(SELECT COUNT(*) FROM word_review_items wri WHERE " + sessionReviews + " AND NOT wri.correct) as incorrect_count",
+}
+
+// sessionKeyset returns the keyset study session lists are paginated by:
+// most recent first, with the session ID breaking ties
+func sessionKeyset(d db.Dialect) []string {
+	return []string{d.Timestamp("ss.created_at"), "ss.id"}
+}
+
+// SessionQuery selects study sessions with their review statistics
+type SessionQuery struct {
+	where  []clause
+	latest bool
+	page   *pagination.Request
+}
+
+// Sessions starts a query for study sessions with their review statistics,
+// most recent first
+func Sessions() *SessionQuery {
+	return &SessionQuery{}
+}
+
+// ID selects a single session
+func (q *SessionQuery) ID(sessionID int) *SessionQuery {
+	q.where = append(q.where, clause{"ss.id = ?", []interface{}{sessionID}})
+	return q
+}
+
+// InGroup selects the sessions studying a group
+func (q *SessionQuery) InGroup(groupID int) *SessionQuery {
+	q.where = append(q.where, clause{"ss.group_id = ?", []interface{}{groupID}})
+	return q
+}
+
+// ForActivity selects the sessions of a study activity
+func (q *SessionQuery) ForActivity(activityID int) *SessionQuery {
+	q.where = append(q.where, clause{"ss.study_activity_id = ?", []interface{}{activityID}})
+	return q
+}
+
+// Latest selects only the most recent session
+func (q *SessionQuery) Latest() *SessionQuery {
+	q.latest = true
+	return q
+}
+
+// Page restricts the query to the rows of a page
+func (q *SessionQuery) Page(page pagination.Request) *SessionQuery {
+	q.page = &page
+	return q
+}
+
+// SQL returns the statement and its arguments
+func (q *SessionQuery) SQL(d db.Dialect) (string, []interface{}) {
+	query := Select(sessionColumns...).
+		From("study_sessions ss").
+		Join("JOIN study_activities sa ON ss.study_activity_id = sa.id").
+		Join("JOIN groups g ON ss.group_id = g.id")
+	query.where = append(query.where, q.where...)
+
+	if q.latest {
+		return query.OrderBy("ss.created_at DESC").Limit(1).SQL()
+	}
+
+	if q.page != nil {
+		keyset, args := q.page.Keyset(sessionKeyset(d), true)
+		query.Where(keyset, args...).Limit(q.page.Fetch())
+	}
+	return query.OrderBy(d.Timestamp("ss.created_at") + " DESC, ss.id DESC").SQL()
+}
+
+// All runs the query and returns every session it selects
+func (q *SessionQuery) All(ctx context.Context, conn db.Querier) ([]models.StudySessionWithStats, error) {
+	query, args := q.SQL(conn.Dialect())
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []models.StudySessionWithStats
+	for rows.Next() {
+		session, err := ScanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// One runs the query and returns the first session it selects, or sql.ErrNoRows
+func (q *SessionQuery) One(ctx context.Context, conn db.Querier) (*models.StudySessionWithStats, error) {
+	query, args := q.SQL(conn.Dialect())
+	session, err := ScanSession(conn.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ScanSession reads a row of a SessionQuery and fills in the score, as a
+// percentage of correct reviews, and the end time, 30 minutes after the start
+func ScanSession(row Scanner) (models.StudySessionWithStats, error) {
+	var session models.StudySessionWithStats
+	err := row.Scan(
+		&session.ID,
+		&session.ActivityName,
+		&session.GroupName,
+		&session.StartTime,
+		&session.ReviewItemsCount,
+		&session.CorrectCount,
+		&session.IncorrectCount,
+	)
+	if err != nil {
+		return session, err
+	}
+
+	if session.ReviewItemsCount > 0 {
+		session.Score = (session.CorrectCount * 100) / session.ReviewItemsCount
+	}
+	session.EndTime = session.StartTime.Add(30 * time.Minute)
+	return session, nil
+}
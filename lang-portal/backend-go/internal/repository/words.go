@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/tags"
+)
+
+// MasteryExpr computes a word's mastery level from its joined review items
+const MasteryExpr = "COALESCE(AVG(CASE WHEN wri.correct THEN 1.0 ELSE 0.0 END), 0)"
+
+// WordSorts lists the sort keys accepted by WordQuery.SortBy
+var WordSorts = []string{"id", "english", "spanish", "correct_count", "incorrect_count", "mastery", "last_reviewed"}
+
+// correctCountExpr and incorrectCountExpr count a word's joined correct and
+// incorrect review items
+const (
+	correctCountExpr   = "COUNT(CASE WHEN wri.correct THEN 1 END)"
+	incorrectCountExpr = "COUNT(CASE WHEN NOT wri.correct THEN 1 END)"
+)
+
+// wordColumns are the columns ScanWord reads, followed by the sort key
+var wordColumns = []string{
+	"w.id",
+	"w.english",
+	"w.spanish",
+	"w.level",
+	"w.created_at",
+	"w.updated_at",
+	correctCountExpr + " as correct_count",
+	incorrectCountExpr + " as incorrect_count",
+	MasteryExpr + " as mastery_level",
+}
+
+// wordSortColumn returns the expression one of WordSorts orders by
+func wordSortColumn(d db.Dialect, sort string) string {
+	switch sort {
+	case "english":
+		return "w.english"
+	case "spanish":
+		return "w.spanish"
+	case "correct_count":
+		return correctCountExpr
+	case "incorrect_count":
+		return incorrectCountExpr
+	case "mastery":
+		return MasteryExpr
+	case "last_reviewed":
+		return "COALESCE(MAX(" + d.Timestamp("wri.created_at") + "), " + d.MinTimestamp() + ")"
+	default:
+		return "w.id"
+	}
+}
+
+// WordQuery selects words with their review statistics
+type WordQuery struct {
+	where        []clause
+	having       []clause
+	createdSince time.Time
+	activityID   int
+	sort         string
+	desc         bool
+	page         *pagination.Request
+}
+
+// Words starts a query for words with their review statistics, ordered by ID
+func Words() *WordQuery {
+	return &WordQuery{sort: "id"}
+}
+
+// ID selects a single word
+func (q *WordQuery) ID(wordID int) *WordQuery {
+	q.where = append(q.where, clause{"w.id = ?", []interface{}{wordID}})
+	return q
+}
+
+// Level selects words of a difficulty level
+func (q *WordQuery) Level(level string) *WordQuery {
+	q.where = append(q.where, clause{"w.level = ?", []interface{}{level}})
+	return q
+}
+
+// InGroup selects the words in a group
+func (q *WordQuery) InGroup(groupID int) *WordQuery {
+	q.where = append(q.where, clause{
+		"EXISTS (SELECT 1 FROM word_groups wg WHERE wg.word_id = w.id AND wg.group_id = ?)",
+		[]interface{}{groupID},
+	})
+	return q
+}
+
+// CreatedSince selects words created at or after t
+func (q *WordQuery) CreatedSince(t time.Time) *WordQuery {
+	q.createdSince = t
+	return q
+}
+
+// Tagged selects words matching a tag query
+func (q *WordQuery) Tagged(query tags.Query) *WordQuery {
+	condition, args := query.SQL("w.id")
+	q.where = append(q.where, clause{condition, args})
+	return q
+}
+
+// MinMastery selects words with a mastery level of at least min
+func (q *WordQuery) MinMastery(min float64) *WordQuery {
+	q.having = append(q.having, clause{MasteryExpr + " >= ?", []interface{}{min}})
+	return q
+}
+
+// MaxMastery selects words with a mastery level of at most max
+func (q *WordQuery) MaxMastery(max float64) *WordQuery {
+	q.having = append(q.having, clause{MasteryExpr + " <= ?", []interface{}{max}})
+	return q
+}
+
+// Reviewed selects words that have, or have not, been reviewed
+func (q *WordQuery) Reviewed(reviewed bool) *WordQuery {
+	if reviewed {
+		q.having = append(q.having, clause{"COUNT(wri.id) > 0", nil})
+	} else {
+		q.having = append(q.having, clause{"COUNT(wri.id) = 0", nil})
+	}
+	return q
+}
+
+// ReviewedIn limits the statistics to reviews made in a study activity
+func (q *WordQuery) ReviewedIn(activityID int) *WordQuery {
+	q.activityID = activityID
+	return q
+}
+
+// SortBy orders the words by one of WordSorts, breaking ties by ID. Unknown
+// sort keys order by ID.
+func (q *WordQuery) SortBy(sort string, desc bool) *WordQuery {
+	if slices.Contains(WordSorts, sort) {
+		q.sort = sort
+	}
+	q.desc = desc
+	return q
+}
+
+// Page restricts the query to the rows of a page
+func (q *WordQuery) Page(page pagination.Request) *WordQuery {
+	q.page = &page
+	return q
+}
+
+// SQL returns the statement and its arguments
+func (q *WordQuery) SQL(d db.Dialect) (string, []interface{}) {
+	sortColumn := wordSortColumn(d, q.sort)
+	direction := "ASC"
+	if q.desc {
+		direction = "DESC"
+	}
+
+	query := Select(append(wordColumns[:len(wordColumns):len(wordColumns)], sortColumn+" as sort_key")...).From("words w")
+	if q.activityID != 0 {
+		query.Join("LEFT JOIN word_review_items wri ON w.id = wri.word_id AND wri.study_activity_id = ?", q.activityID)
+	} else {
+		query.Join("LEFT JOIN word_review_items wri ON w.id = wri.word_id")
+	}
+	query.where = append(query.where, q.where...)
+	if !q.createdSince.IsZero() {
+		query.Where(d.Timestamp("w.created_at")+" >= ?", q.createdSince.UTC().Format("2006-01-02 15:04:05"))
+	}
+	query.GroupBy("w.id")
+	query.having = append(query.having, q.having...)
+	if q.page != nil {
+		// The sort column may be an aggregate, so the keyset is a HAVING condition
+		keyset, args := q.page.Keyset([]string{sortColumn, "w.id"}, q.desc)
+		query.Having(keyset, args...)
+		query.Limit(q.page.Fetch())
+	}
+	query.OrderBy(sortColumn + " " + direction + ", w.id " + direction)
+	return query.SQL()
+}
+
+// All runs the query and returns every word it selects. Each word's SortKey
+// holds the value it was ordered by.
+func (q *WordQuery) All(ctx context.Context, conn db.Querier) ([]models.WordWithStats, error) {
+	query, args := q.SQL(conn.Dialect())
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []models.WordWithStats
+	for rows.Next() {
+		word, err := ScanWord(rows)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+	}
+	return words, rows.Err()
+}
+
+// One runs the query and returns the first word it selects, or sql.ErrNoRows
+func (q *WordQuery) One(ctx context.Context, conn db.Querier) (*models.WordWithStats, error) {
+	query, args := q.SQL(conn.Dialect())
+	word, err := ScanWord(conn.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return nil, err
+	}
+	return &word, nil
+}
+
+// ScanWord reads a row of a WordQuery
+func ScanWord(row Scanner) (models.WordWithStats, error) {
+	var word models.WordWithStats
+	err := row.Scan(
+		&word.ID,
+		&word.English,
+		&word.Spanish,
+		&word.Level,
+		&word.CreatedAt,
+		&word.UpdatedAt,
+		&word.CorrectCount,
+		&word.IncorrectCount,
+		&word.MasteryLevel,
+		&word.SortKey,
+	)
+	if b, ok := word.SortKey.([]byte); ok {
+		word.SortKey = string(b)
+	}
+	return word, err
+}
@@ -0,0 +1,65 @@
+// Package requestid assigns every request an ID, taken from the X-Request-ID
+// header when the client sends a usable one
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Header is the request and response header carrying the ID
+const Header = "X-Request-ID"
+
+// maxLength bounds the IDs accepted from clients
+const maxLength = 128
+
+type contextKey struct{}
+
+// Middleware propagates the client's request ID or assigns a new one, echoing
+// it in the response and storing it in the request context
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(Header)
+		if !valid(id) {
+			id = generate()
+		}
+		c.Writer.Header().Set(Header, id)
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// NewContext returns a copy of ctx carrying the request ID
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, or "" if there is none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// valid reports whether a client supplied ID is short and printable ASCII,
+// so it can safely be echoed and logged
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// generate returns a random 128-bit ID in hex
+func generate() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
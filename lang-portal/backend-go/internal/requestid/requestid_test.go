@@ -0,0 +1,51 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddleware(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware())
+	var seen string
+	r.GET("/", func(c *gin.Context) {
+		seen = FromContext(c.Request.Context())
+	})
+	request := func(header string) string {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set(Header, header)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if got := w.Header().Get(Header); got != seen {
+			t.Errorf("Response carries ID %q, handler saw %q", got, seen)
+		}
+		return seen
+	}
+
+	// Test assigned IDs
+	first, second := request(""), request("")
+	if len(first) != 32 || first == second {
+		t.Errorf("Expected distinct 32 character IDs, got %q and %q", first, second)
+	}
+
+	// Test propagated ID
+	if got := request("client-123"); got != "client-123" {
+		t.Errorf("Expected the client's ID, got %q", got)
+	}
+
+	// Test unusable IDs are replaced
+	for _, id := range []string{"has space", strings.Repeat("x", maxLength+1), "café"} {
+		if got := request(id); got == id {
+			t.Errorf("Expected %q to be replaced", id)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Server wraps an http.Server with graceful shutdown. When the context passed
+// to Serve is cancelled the server stops accepting connections and waits up
+// to the drain timeout for in-flight requests before cancelling them.
+type Server struct {
+	srv          *http.Server
+	drainTimeout time.Duration
+}
+
+// New creates a server for handler listening on addr
+func New(addr string, handler http.Handler, drainTimeout time.Duration) *Server {
+	return &Server{
+		srv: &http.Server{
+			Addr:              addr,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		drainTimeout: drainTimeout,
+	}
+}
+
+// ListenAndServe listens on the configured address and serves until ctx is done
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.srv.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve accepts connections on ln until ctx is done, then drains in-flight
+// requests. It returns nil when every request finished within the drain timeout.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	// Request contexts derive from base so they can be cancelled if draining times out
+	base, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	s.srv.BaseContext = func(net.Listener) context.Context { return base }
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.drainTimeout)
+	defer cancel()
+	if err := s.srv.Shutdown(shutdownCtx); err != nil {
+		cancelRequests()
+		s.srv.Close()
+		return fmt.Errorf("requests still running after %s: %w", s.drainTimeout, err)
+	}
+
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
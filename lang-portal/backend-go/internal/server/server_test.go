@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startServer serves handler on a random local port until the returned
+// cancel function is called; the server's result is sent on the channel
+func startServer(t *testing.T, handler http.Handler, drainTimeout time.Duration) (string, context.CancelFunc, <-chan error) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- New(ln.Addr().String(), handler, drainTimeout).Serve(ctx, ln)
+	}()
+	return "http://" + ln.Addr().String(), cancel, done
+}
+
+func TestServeDrainsInFlightRequests(t *testing.T) {
+	// Setup
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		if r.Context().Err() != nil {
+			t.Error("Expected request context to stay alive while draining")
+		}
+		io.WriteString(w, "done")
+	})
+	url, cancel, done := startServer(t, handler, 5*time.Second)
+
+	// Test that a request running when shutdown starts still completes
+	type result struct {
+		body string
+		err  error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		responses <- result{string(body), err}
+	}()
+
+	<-started
+	cancel()
+
+	res := <-responses
+	if res.err != nil || res.body != "done" {
+		t.Errorf("Expected in-flight request to complete, got %q, %v", res.body, res.err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Expected clean shutdown, got %v", err)
+	}
+
+	// Test that new connections are refused once the server has stopped
+	if _, err := http.Get(url); err == nil {
+		t.Error("Expected request after shutdown to fail")
+	}
+}
+
+func TestServeCancelsRequestsAfterDrainTimeout(t *testing.T) {
+	// Setup
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		select {
+		case <-r.Context().Done():
+			close(cancelled)
+		case <-time.After(5 * time.Second):
+		}
+	})
+	url, cancel, done := startServer(t, handler, 50*time.Millisecond)
+
+	// Test
+	go func() {
+		if resp, err := http.Get(url); err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	<-started
+	cancel()
+
+	err := <-done
+	if err == nil || !strings.Contains(err.Error(), "requests still running") {
+		t.Errorf("Expected drain timeout error, got %v", err)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("Expected request context to be cancelled after the drain timeout")
+	}
+}
@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/metrics"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+)
+
+// GetStudyActivity returns a study activity with its session count and group IDs
+func (s *SQL) GetStudyActivity(ctx context.Context, activityID int) (*models.StudyActivityWithStats, error) {
+	defer metrics.ObserveQuery("GetStudyActivity", time.Now())
+	var activity models.StudyActivityWithStats
+	err := s.db.QueryRowContext(ctx, `
+		SELECT
+			sa.id,
+			sa.name,
+			sa.description,
+			sa.created_at,
+			sa.updated_at,
+			COUNT(DISTINCT ss.id) as total_sessions
+		FROM study_activities sa
+		LEFT JOIN study_sessions ss ON sa.id = ss.study_activity_id
+		WHERE sa.id = ?
+		GROUP BY sa.id
+	`, activityID).Scan(
+		&activity.ID,
+		&activity.Name,
+		&activity.Description,
+		&activity.CreatedAt,
+		&activity.UpdatedAt,
+		&activity.TotalSessions,
+	)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	activity.GroupIDs, err = s.listActivityGroupIDs(ctx, activityID)
+	if err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+// FindStudyActivity returns a study activity with the IDs of its groups,
+// without counting its sessions
+func (s *SQL) FindStudyActivity(ctx context.Context, activityID int) (*models.StudyActivityWithStats, error) {
+	defer metrics.ObserveQuery("FindStudyActivity", time.Now())
+	var activity models.StudyActivityWithStats
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, name, description, created_at, updated_at
+		FROM study_activities
+		WHERE id = ?
+	`, activityID).Scan(&activity.ID, &activity.Name, &activity.Description, &activity.CreatedAt, &activity.UpdatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	activity.GroupIDs, err = s.listActivityGroupIDs(ctx, activityID)
+	if err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+// CreateStudyActivity creates a study activity linked to the given groups
+// and returns its ID
+func (s *SQL) CreateStudyActivity(ctx context.Context, name, description string, groupIDs []int) (int, error) {
+	defer metrics.ObserveQuery("CreateStudyActivity", time.Now())
+	var activityID int64
+	err := s.withTx(ctx, func(tx *db.Tx) error {
+		var err error
+		activityID, err = db.Insert(ctx, tx, `
+			INSERT INTO study_activities (name, description)
+			VALUES (?, ?)
+		`, name, description)
+		if err != nil {
+			return err
+		}
+
+		// Link activity to groups
+		for _, groupID := range groupIDs {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO study_activity_groups (study_activity_id, group_id)
+				VALUES (?, ?)
+			`, activityID, groupID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return int(activityID), err
+}
+
+// listActivityGroupIDs returns the IDs of the groups linked to an activity
+func (s *SQL) listActivityGroupIDs(ctx context.Context, activityID int) ([]int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT group_id
+		FROM study_activity_groups
+		WHERE study_activity_id = ?
+	`, activityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groupIDs []int
+	for rows.Next() {
+		var groupID int
+		if err := rows.Scan(&groupID); err != nil {
+			return nil, err
+		}
+		groupIDs = append(groupIDs, groupID)
+	}
+	return groupIDs, rows.Err()
+}
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/metrics"
+)
+
+// Backup copies the database into a new file at path while it stays online.
+// It returns ErrUnsupported for databases other than SQLite.
+func (s *SQL) Backup(ctx context.Context, path string) error {
+	defer metrics.ObserveQuery("Backup", time.Now())
+	return backupError(s.db.Backup(ctx, path))
+}
+
+// Restore replaces the database contents with the backup at path. It
+// returns ErrIncompatible if the backup is at another schema version, and
+// ErrUnsupported for databases other than SQLite.
+func (s *SQL) Restore(ctx context.Context, path string) error {
+	defer metrics.ObserveQuery("Restore", time.Now())
+	return backupError(s.db.Restore(ctx, path))
+}
+
+// backupError maps the db package's backup errors to the store's
+func backupError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrBackupUnsupported):
+		return ErrUnsupported
+	case errors.Is(err, db.ErrSchemaMismatch):
+		return ErrIncompatible
+	}
+	return err
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/metrics"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/quiz"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/repository"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/tags"
+)
+
+// ListGroups returns word groups with their statistics, ordered by name
+func (s *SQL) ListGroups(ctx context.Context, page pagination.Request) ([]models.GroupWithStats, error) {
+	defer metrics.ObserveQuery("ListGroups", time.Now())
+	return repository.Groups().Page(page).All(ctx, s.db)
+}
+
+// GetGroup returns a word group with its statistics
+func (s *SQL) GetGroup(ctx context.Context, groupID int) (*models.GroupWithStats, error) {
+	defer metrics.ObserveQuery("GetGroup", time.Now())
+	group, err := repository.Groups().ID(groupID).One(ctx, s.db)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return group, nil
+}
+
+// ListGroupWords returns the words in a group with their statistics, ordered
+// by English. It returns ErrNotFound if the group does not exist.
+func (s *SQL) ListGroupWords(ctx context.Context, groupID int, page pagination.Request) ([]models.WordWithStats, error) {
+	defer metrics.ObserveQuery("ListGroupWords", time.Now())
+	exists, err := s.exists(ctx, "SELECT 1 FROM groups WHERE id = ?", groupID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	words, err := repository.Words().InGroup(groupID).SortBy("english", false).Page(page).All(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachWordDetails(ctx, words); err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+// FindGroupWord returns a word, provided it belongs to the group
+func (s *SQL) FindGroupWord(ctx context.Context, groupID, wordID int) (*models.Word, error) {
+	defer metrics.ObserveQuery("FindGroupWord", time.Now())
+	var word models.Word
+	err := s.db.QueryRowContext(ctx, `
+		SELECT w.id, w.english, w.spanish, w.level, w.created_at, w.updated_at
+		FROM words w
+		JOIN word_groups wg ON w.id = wg.word_id
+		WHERE w.id = ? AND wg.group_id = ?
+	`, wordID, groupID).Scan(&word.ID, &word.English, &word.Spanish, &word.Level, &word.CreatedAt, &word.UpdatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &word, nil
+}
+
+// ListQuizWords returns the words in a group along with their first image
+func (s *SQL) ListQuizWords(ctx context.Context, groupID int) ([]quiz.Word, error) {
+	defer metrics.ObserveQuery("ListQuizWords", time.Now())
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT
+			w.id,
+			w.english,
+			w.spanish,
+			(SELECT MIN(wi.image_id) FROM word_images wi WHERE wi.word_id = w.id) as image_id
+		FROM words w
+		JOIN word_groups wg ON w.id = wg.word_id
+		WHERE wg.group_id = ?
+		ORDER BY w.id
+	`, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []quiz.Word
+	for rows.Next() {
+		var word quiz.Word
+		var imageID sql.NullInt64
+		if err := rows.Scan(&word.ID, &word.English, &word.Spanish, &imageID); err != nil {
+			return nil, err
+		}
+		if imageID.Valid {
+			word.ImageURL, word.ImageThumbnailURL = models.ImageURLs(int(imageID.Int64))
+		}
+		words = append(words, word)
+	}
+	return words, rows.Err()
+}
+
+// MissingGroups returns those of the given group IDs that do not exist
+func (s *SQL) MissingGroups(ctx context.Context, groupIDs []int) ([]int, error) {
+	defer metrics.ObserveQuery("MissingGroups", time.Now())
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	placeholders, args := inList(groupIDs)
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id
+		FROM groups
+		WHERE id IN (`+placeholders+`)
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make(map[int]bool, len(groupIDs))
+	for rows.Next() {
+		var groupID int
+		if err := rows.Scan(&groupID); err != nil {
+			return nil, err
+		}
+		found[groupID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var missing []int
+	for _, groupID := range groupIDs {
+		if !found[groupID] {
+			missing = append(missing, groupID)
+		}
+	}
+	return missing, nil
+}
+
+// CreateGroup creates an empty word group
+func (s *SQL) CreateGroup(ctx context.Context, name string) (*models.Group, error) {
+	defer metrics.ObserveQuery("CreateGroup", time.Now())
+	groupID, err := db.Insert(ctx, s.db, `
+		INSERT INTO groups (name, created_at, updated_at)
+		VALUES (?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, name)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Group{ID: int(groupID), Name: name}, nil
+}
+
+// CreateGroupFromTags creates a group containing every word matching query
+// and returns its ID and the number of words added
+func (s *SQL) CreateGroupFromTags(ctx context.Context, name string, query tags.Query) (int, int64, error) {
+	defer metrics.ObserveQuery("CreateGroupFromTags", time.Now())
+	var groupID, wordCount int64
+	err := s.withTx(ctx, func(tx *db.Tx) error {
+		var err error
+		groupID, err = db.Insert(ctx, tx, `
+			INSERT INTO groups (name, created_at, updated_at)
+			VALUES (?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`, name)
+		if err != nil {
+			return err
+		}
+
+		// Add the matching words to the group
+		condition, args := query.SQL("w.id")
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO word_groups (word_id, group_id)
+			SELECT w.id, ? FROM words w
+			WHERE `+condition+`
+			ORDER BY w.id
+		`, append([]interface{}{groupID}, args...)...)
+		if err != nil {
+			return err
+		}
+		wordCount, err = result.RowsAffected()
+		return err
+	})
+	return int(groupID), wordCount, err
+}
@@ -0,0 +1,303 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/metrics"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+)
+
+// CreateWordAudio stores a pronunciation clip for a word
+func (s *SQL) CreateWordAudio(ctx context.Context, wordID int, r io.Reader) (*models.WordAudio, error) {
+	defer metrics.ObserveQuery("CreateWordAudio", time.Now())
+	stored, err := s.media.SaveAudio(wordID, r)
+	if err != nil {
+		return nil, err
+	}
+
+	audioID, err := db.Insert(ctx, s.db, `
+		INSERT INTO word_audio (word_id, filename, content_type, size)
+		VALUES (?, ?, ?, ?)
+	`, wordID, stored.Filename, stored.ContentType, stored.Size)
+	if err != nil {
+		if err := s.media.Remove(stored.Filename); err != nil {
+			slog.ErrorContext(ctx, "Failed to remove audio file", "file", stored.Filename, "err", err)
+		}
+		return nil, err
+	}
+
+	return s.GetWordAudio(ctx, wordID, int(audioID))
+}
+
+// GetWordAudio returns a pronunciation clip of a word, including its filename
+func (s *SQL) GetWordAudio(ctx context.Context, wordID, audioID int) (*models.WordAudio, error) {
+	defer metrics.ObserveQuery("GetWordAudio", time.Now())
+	audio := models.WordAudio{WordID: wordID}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, filename, content_type, size, created_at
+		FROM word_audio
+		WHERE id = ? AND word_id = ?
+	`, audioID, wordID).Scan(&audio.ID, &audio.Filename, &audio.ContentType, &audio.Size, &audio.CreatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	audio.URL = models.AudioURL(wordID, audio.ID)
+	return &audio, nil
+}
+
+// DeleteWordAudio removes a pronunciation clip and its file
+func (s *SQL) DeleteWordAudio(ctx context.Context, wordID, audioID int) error {
+	defer metrics.ObserveQuery("DeleteWordAudio", time.Now())
+	audio, err := s.GetWordAudio(ctx, wordID, audioID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM word_audio WHERE id = ?", audioID); err != nil {
+		return err
+	}
+
+	if err := s.media.Remove(audio.Filename); err != nil {
+		slog.ErrorContext(ctx, "Failed to remove audio file", "file", audio.Filename, "err", err)
+	}
+	return nil
+}
+
+// CreateWordImage links a picture to a word, reusing any identical image
+// already stored. The boolean reports whether a new link was created.
+func (s *SQL) CreateWordImage(ctx context.Context, wordID int, data []byte) (*models.Image, bool, error) {
+	defer metrics.ObserveQuery("CreateWordImage", time.Now())
+	// Files are named by content hash, so concurrent uploads of the same
+	// image write the same files
+	stored, err := s.media.SaveImage(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Record the image unless it exists and link it in one transaction, so
+	// a concurrent upload of the same image cannot break the UNIQUE hash.
+	// Orphaned images are removed in a transaction too, which has committed
+	// by the time these writes go through, so if one took the files since
+	// they were saved they are written again.
+	var imageID int
+	var linked bool
+	err = s.withTx(ctx, func(tx *db.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO images (sha256, filename, thumbnail_filename, content_type, width, height, size)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (sha256) DO NOTHING
+		`, stored.SHA256, stored.Filename, stored.ThumbnailFilename, stored.ContentType, stored.Width, stored.Height, stored.Size)
+		if err != nil {
+			return err
+		}
+		if err := tx.QueryRowContext(ctx, "SELECT id FROM images WHERE sha256 = ?", stored.SHA256).Scan(&imageID); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO word_images (word_id, image_id)
+			VALUES (?, ?)
+			ON CONFLICT DO NOTHING
+		`, wordID, imageID)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		linked = err == nil && affected > 0
+
+		if !s.media.Stored(stored) {
+			_, err := s.media.SaveImage(data)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	image, err := s.GetImage(ctx, imageID)
+	if err != nil {
+		return nil, false, err
+	}
+	return image, linked, nil
+}
+
+// GetImage returns an image, including its filenames
+func (s *SQL) GetImage(ctx context.Context, imageID int) (*models.Image, error) {
+	defer metrics.ObserveQuery("GetImage", time.Now())
+	var image models.Image
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, sha256, filename, thumbnail_filename, content_type, width, height, size, created_at
+		FROM images
+		WHERE id = ?
+	`, imageID).Scan(
+		&image.ID,
+		&image.SHA256,
+		&image.Filename,
+		&image.ThumbnailFilename,
+		&image.ContentType,
+		&image.Width,
+		&image.Height,
+		&image.Size,
+		&image.CreatedAt,
+	)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	image.URL, image.ThumbnailURL = models.ImageURLs(image.ID)
+	return &image, nil
+}
+
+// DeleteWordImage unlinks an image from a word, removing the image once no word uses it
+func (s *SQL) DeleteWordImage(ctx context.Context, wordID, imageID int) error {
+	defer metrics.ObserveQuery("DeleteWordImage", time.Now())
+	result, err := s.db.ExecContext(ctx, "DELETE FROM word_images WHERE word_id = ? AND image_id = ?", wordID, imageID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNotFound
+	}
+
+	if err := s.removeOrphanImages(ctx, []int{imageID}); err != nil {
+		slog.ErrorContext(ctx, "Failed to remove orphaned images", "err", err)
+	}
+	return nil
+}
+
+// listWordAudio returns the pronunciation clips attached to a word
+func (s *SQL) listWordAudio(ctx context.Context, wordID int) ([]models.WordAudio, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, word_id, content_type, size, created_at
+		FROM word_audio
+		WHERE word_id = ?
+		ORDER BY id
+	`, wordID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	audio := []models.WordAudio{}
+	for rows.Next() {
+		var clip models.WordAudio
+		if err := rows.Scan(&clip.ID, &clip.WordID, &clip.ContentType, &clip.Size, &clip.CreatedAt); err != nil {
+			return nil, err
+		}
+		clip.URL = models.AudioURL(clip.WordID, clip.ID)
+		audio = append(audio, clip)
+	}
+	return audio, rows.Err()
+}
+
+// listWordAudioFiles returns the stored filenames of a word's pronunciation clips
+func (s *SQL) listWordAudioFiles(ctx context.Context, wordID int) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT filename FROM word_audio WHERE word_id = ?", wordID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var filenames []string
+	for rows.Next() {
+		var filename string
+		if err := rows.Scan(&filename); err != nil {
+			return nil, err
+		}
+		filenames = append(filenames, filename)
+	}
+	return filenames, rows.Err()
+}
+
+// listWordImageIDs returns the IDs of the images linked to a word
+func (s *SQL) listWordImageIDs(ctx context.Context, wordID int) ([]int, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT image_id FROM word_images WHERE word_id = ?", wordID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var imageIDs []int
+	for rows.Next() {
+		var imageID int
+		if err := rows.Scan(&imageID); err != nil {
+			return nil, err
+		}
+		imageIDs = append(imageIDs, imageID)
+	}
+	return imageIDs, rows.Err()
+}
+
+// listMediaFiles returns every stored audio clip, image and thumbnail file
+func (s *SQL) listMediaFiles(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT filename FROM word_audio
+		UNION ALL SELECT filename FROM images
+		UNION ALL SELECT thumbnail_filename FROM images
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var files []string
+	for rows.Next() {
+		var filename string
+		if err := rows.Scan(&filename); err != nil {
+			return nil, err
+		}
+		files = append(files, filename)
+	}
+	return files, rows.Err()
+}
+
+// removeOrphanImages deletes images, and their files, that no word links to
+// any more. Each image is only deleted if it is still unlinked when the
+// statement runs, and its files are removed before the transaction commits,
+// so an upload of the same image linking it concurrently either keeps it or
+// finds its files gone and writes them again.
+func (s *SQL) removeOrphanImages(ctx context.Context, imageIDs []int) error {
+	for _, imageID := range imageIDs {
+		err := s.withTx(ctx, func(tx *db.Tx) error {
+			var filename, thumbnailFilename string
+			err := tx.QueryRowContext(ctx, "SELECT filename, thumbnail_filename FROM images WHERE id = ?", imageID).Scan(&filename, &thumbnailFilename)
+			if err == sql.ErrNoRows {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			result, err := tx.ExecContext(ctx, `
+				DELETE FROM images
+				WHERE id = ? AND NOT EXISTS (SELECT 1 FROM word_images WHERE image_id = images.id)
+			`, imageID)
+			if err != nil {
+				return err
+			}
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if affected != 1 {
+				// Linked again since it was unlinked
+				return nil
+			}
+
+			for _, file := range []string{filename, thumbnailFilename} {
+				if err := s.media.Remove(file); err != nil {
+					slog.ErrorContext(ctx, "Failed to remove image file", "file", file, "err", err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
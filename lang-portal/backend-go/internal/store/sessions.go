@@ -0,0 +1,130 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/metrics"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/repository"
+)
+
+// ListStudySessions returns study sessions with their statistics, most recent first
+func (s *SQL) ListStudySessions(ctx context.Context, filter SessionFilter, page pagination.Request) ([]models.StudySessionWithStats, error) {
+	defer metrics.ObserveQuery("ListStudySessions", time.Now())
+	query := repository.Sessions().Page(page)
+	if filter.GroupID != 0 {
+		query.InGroup(filter.GroupID)
+	}
+	if filter.ActivityID != 0 {
+		query.ForActivity(filter.ActivityID)
+	}
+	return query.All(ctx, s.db)
+}
+
+// GetStudySession returns a study session with its statistics
+func (s *SQL) GetStudySession(ctx context.Context, sessionID int) (*models.StudySessionWithStats, error) {
+	defer metrics.ObserveQuery("GetStudySession", time.Now())
+	session, err := repository.Sessions().ID(sessionID).One(ctx, s.db)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return session, nil
+}
+
+// LastStudySession returns the most recent study session with its statistics
+func (s *SQL) LastStudySession(ctx context.Context) (*models.StudySessionWithStats, error) {
+	defer metrics.ObserveQuery("LastStudySession", time.Now())
+	session, err := repository.Sessions().Latest().One(ctx, s.db)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return session, nil
+}
+
+// FindStudySession returns a study session without statistics
+func (s *SQL) FindStudySession(ctx context.Context, sessionID int) (*models.StudySession, error) {
+	defer metrics.ObserveQuery("FindStudySession", time.Now())
+	var session models.StudySession
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, group_id, study_activity_id, created_at
+		FROM study_sessions
+		WHERE id = ?
+	`, sessionID).Scan(&session.ID, &session.GroupID, &session.StudyActivityID, &session.CreatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &session, nil
+}
+
+// CreateStudySession starts a study session for the first group of an
+// activity. It returns ErrNotFound if the activity has no groups.
+func (s *SQL) CreateStudySession(ctx context.Context, activityID int, startTime time.Time) (*models.StudySession, error) {
+	defer metrics.ObserveQuery("CreateStudySession", time.Now())
+	groupIDs, err := s.listActivityGroupIDs(ctx, activityID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupIDs) == 0 {
+		return nil, ErrNotFound
+	}
+
+	sessionID, err := db.Insert(ctx, s.db, `
+		INSERT INTO study_sessions (study_activity_id, group_id, created_at)
+		VALUES (?, ?, ?)
+	`, activityID, groupIDs[0], startTime)
+	if err != nil {
+		return nil, err
+	}
+	metrics.SessionStarted()
+
+	return &models.StudySession{
+		ID:              int(sessionID),
+		GroupID:         groupIDs[0],
+		StudyActivityID: activityID,
+		CreatedAt:       startTime,
+	}, nil
+}
+
+// ListSessionWords returns the words in a session's group with their
+// statistics for the session's activity, ordered by English
+func (s *SQL) ListSessionWords(ctx context.Context, session *models.StudySession, page pagination.Request) ([]models.WordWithStats, error) {
+	defer metrics.ObserveQuery("ListSessionWords", time.Now())
+	words, err := repository.Words().
+		InGroup(session.GroupID).
+		ReviewedIn(session.StudyActivityID).
+		SortBy("english", false).
+		Page(page).
+		All(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachWordDetails(ctx, words); err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+// RecordReview inserts a word review item and returns the word's new mastery level
+func (s *SQL) RecordReview(ctx context.Context, wordID, activityID int, correct bool, responseTime float64) (float64, error) {
+	defer metrics.ObserveQuery("RecordReview", time.Now())
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO word_review_items (word_id, study_activity_id, correct, response_time)
+		VALUES (?, ?, ?, ?)
+	`, wordID, activityID, correct, responseTime)
+	if err != nil {
+		return 0, err
+	}
+	metrics.ReviewRecorded(correct)
+
+	var masteryLevel float64
+	err = s.db.QueryRowContext(ctx, `
+		SELECT `+repository.MasteryExpr+` as mastery_level
+		FROM word_review_items wri
+		WHERE wri.word_id = ?
+	`, wordID).Scan(&masteryLevel)
+	return masteryLevel, err
+}
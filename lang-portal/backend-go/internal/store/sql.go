@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+)
+
+// SQL is the Store implementation backed by a SQLite or PostgreSQL database,
+// keeping uploaded audio and images in a media directory
+type SQL struct {
+	db    *db.Conn
+	media media.Dir
+}
+
+var _ Store = (*SQL)(nil)
+
+// New returns a Store using the given connection and media directory
+func New(conn *db.Conn, mediaDir media.Dir) *SQL {
+	return &SQL{db: conn, media: mediaDir}
+}
+
+// NewSQLite returns a Store using the given SQLite connection and media directory
+func NewSQLite(conn *sql.DB, mediaDir media.Dir) *SQL {
+	return New(db.NewConn(conn, db.SQLite), mediaDir)
+}
+
+// NewPostgres returns a Store using the given PostgreSQL connection and media directory
+func NewPostgres(conn *sql.DB, mediaDir media.Dir) *SQL {
+	return New(db.NewConn(conn, db.Postgres), mediaDir)
+}
+
+// Close closes the database, first checkpointing the write-ahead log of a
+// SQLite database
+func (s *SQL) Close() error {
+	if s.db.Dialect() == db.SQLite {
+		if err := db.Checkpoint(s.db.DB()); err != nil {
+			slog.Error("Failed to checkpoint database", "err", err)
+		}
+	}
+	return s.db.Close()
+}
+
+// withTx runs fn in a transaction, committing if it succeeds and rolling
+// back otherwise
+func (s *SQL) withTx(ctx context.Context, fn func(tx *db.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			slog.ErrorContext(ctx, "Failed to roll back transaction", "err", err)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// exists reports whether query returns a row
+func (s *SQL) exists(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, "SELECT EXISTS("+query+")", args...).Scan(&exists)
+	return exists, err
+}
+
+// notFound maps sql.ErrNoRows to ErrNotFound
+func notFound(err error) error {
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	return err
+}
+
+// inList returns a placeholder list and arguments for an IN clause over ids
+func inList(ids []int) (string, []interface{}) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	return strings.Join(placeholders, ", "), args
+}
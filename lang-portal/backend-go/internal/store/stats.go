@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/metrics"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+)
+
+// StudyProgress returns word counts and the average mastery level
+func (s *SQL) StudyProgress(ctx context.Context) (*models.StudyProgress, error) {
+	defer metrics.ObserveQuery("StudyProgress", time.Now())
+	var progress models.StudyProgress
+	err := s.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM words) as total_words,
+			(SELECT COUNT(DISTINCT word_id) FROM word_review_items) as words_studied,
+			(SELECT COALESCE(AVG(CASE WHEN correct THEN 1.0 ELSE 0.0 END), 0) FROM word_review_items) as average_mastery
+	`).Scan(
+		&progress.TotalWords,
+		&progress.WordsStudied,
+		&progress.AverageMastery,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// QuickStats returns session, review and word counts
+func (s *SQL) QuickStats(ctx context.Context) (*models.QuickStats, error) {
+	defer metrics.ObserveQuery("QuickStats", time.Now())
+	var stats models.QuickStats
+	err := s.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM study_sessions) as total_sessions,
+			(SELECT COUNT(*) FROM word_review_items) as total_reviews,
+			(SELECT COUNT(*) FROM words) as total_words,
+			(SELECT COUNT(DISTINCT word_id) FROM word_review_items) as words_studied,
+			(SELECT COALESCE(AVG(CASE WHEN correct THEN 1.0 ELSE 0.0 END), 0) FROM word_review_items) as average_mastery
+	`).Scan(
+		&stats.TotalSessions,
+		&stats.TotalReviews,
+		&stats.TotalWords,
+		&stats.WordsStudied,
+		&stats.AverageMastery,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// ResetHistory deletes all study sessions and word review items
+func (s *SQL) ResetHistory(ctx context.Context) error {
+	defer metrics.ObserveQuery("ResetHistory", time.Now())
+	return s.withTx(ctx, func(tx *db.Tx) error {
+		for _, table := range []string{"word_review_items", "study_sessions"} {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM "+table); err != nil {
+				return fmt.Errorf("failed to delete data from %s: %w", table, err)
+			}
+		}
+		return nil
+	})
+}
+
+// FullReset deletes all data and resets the auto-increment counters, then
+// runs the seed script in the same transaction. Media files are removed once
+// their rows are gone.
+func (s *SQL) FullReset(ctx context.Context, seed string) error {
+	defer metrics.ObserveQuery("FullReset", time.Now())
+	// Delete all data from tables in the correct order
+	tables := []string{
+		"word_review_items",
+		"study_sessions",
+		"word_groups",
+		"study_activity_groups",
+		"word_tags",
+		"word_audio",
+		"word_images",
+		"words",
+		"images",
+		"tags",
+		"groups",
+		"study_activities",
+	}
+
+	files, err := s.listMediaFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.withTx(ctx, func(tx *db.Tx) error {
+		for _, table := range tables {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM "+table); err != nil {
+				return fmt.Errorf("failed to delete data from %s: %w", table, err)
+			}
+		}
+
+		// Reset auto-increment counters
+		for _, table := range tables {
+			query, args := s.db.Dialect().ResetSequence(table)
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return fmt.Errorf("failed to reset auto-increment for %s: %w", table, err)
+			}
+		}
+
+		for _, stmt := range db.Statements(seed) {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf("failed to seed database: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, filename := range files {
+		if err := s.media.Remove(filename); err != nil {
+			slog.ErrorContext(ctx, "Failed to remove media file", "file", filename, "err", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/quiz"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/repository"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/tags"
+)
+
+var (
+	// ErrNotFound is returned when the requested record does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a record would duplicate an existing one
+	ErrConflict = errors.New("already exists")
+	// ErrUnsupported is returned when the database cannot perform an operation
+	ErrUnsupported = errors.New("not supported by this database")
+	// ErrIncompatible is returned when restoring a backup of another schema version
+	ErrIncompatible = errors.New("backup schema version does not match the database")
+)
+
+// Store is the persistence layer behind the API handlers. Every list method
+// returns up to page.Fetch() items so the caller can tell whether another
+// page follows.
+type Store interface {
+	WordStore
+	GroupStore
+	SessionStore
+	ReviewStore
+	ActivityStore
+	TagStore
+	MediaStore
+	StatsStore
+	BackupStore
+	TransferStore
+
+	// Close releases the underlying database
+	Close() error
+}
+
+// WordStore reads and deletes vocabulary words
+type WordStore interface {
+	ListWords(ctx context.Context, filter WordFilter, page pagination.Request) ([]models.WordWithStats, error)
+	GetWord(ctx context.Context, wordID int) (*models.WordDetail, error)
+	FindWord(ctx context.Context, wordID int) (*models.Word, error)
+	DeleteWord(ctx context.Context, wordID int) error
+}
+
+// GroupStore reads and creates word groups
+type GroupStore interface {
+	ListGroups(ctx context.Context, page pagination.Request) ([]models.GroupWithStats, error)
+	GetGroup(ctx context.Context, groupID int) (*models.GroupWithStats, error)
+	ListGroupWords(ctx context.Context, groupID int, page pagination.Request) ([]models.WordWithStats, error)
+	FindGroupWord(ctx context.Context, groupID, wordID int) (*models.Word, error)
+	MissingGroups(ctx context.Context, groupIDs []int) ([]int, error)
+	ListQuizWords(ctx context.Context, groupID int) ([]quiz.Word, error)
+	CreateGroup(ctx context.Context, name string) (*models.Group, error)
+	CreateGroupFromTags(ctx context.Context, name string, query tags.Query) (int, int64, error)
+}
+
+// SessionStore reads and creates study sessions
+type SessionStore interface {
+	ListStudySessions(ctx context.Context, filter SessionFilter, page pagination.Request) ([]models.StudySessionWithStats, error)
+	GetStudySession(ctx context.Context, sessionID int) (*models.StudySessionWithStats, error)
+	LastStudySession(ctx context.Context) (*models.StudySessionWithStats, error)
+	FindStudySession(ctx context.Context, sessionID int) (*models.StudySession, error)
+	CreateStudySession(ctx context.Context, activityID int, startTime time.Time) (*models.StudySession, error)
+	ListSessionWords(ctx context.Context, session *models.StudySession, page pagination.Request) ([]models.WordWithStats, error)
+}
+
+// ReviewStore records word reviews
+type ReviewStore interface {
+	RecordReview(ctx context.Context, wordID, activityID int, correct bool, responseTime float64) (float64, error)
+}
+
+// ActivityStore reads and creates study activities
+type ActivityStore interface {
+	GetStudyActivity(ctx context.Context, activityID int) (*models.StudyActivityWithStats, error)
+	FindStudyActivity(ctx context.Context, activityID int) (*models.StudyActivityWithStats, error)
+	CreateStudyActivity(ctx context.Context, name, description string, groupIDs []int) (int, error)
+}
+
+// TagStore manages tags and the words they are attached to
+type TagStore interface {
+	ListTags(ctx context.Context, page pagination.Request) ([]models.TagWithStats, error)
+	CreateTag(ctx context.Context, name string) (*models.Tag, error)
+	UpdateTag(ctx context.Context, tagID int, name string) (*models.Tag, error)
+	DeleteTag(ctx context.Context, tagID int) error
+	AddWordTags(ctx context.Context, wordID int, names []string) ([]string, error)
+	DeleteWordTag(ctx context.Context, wordID, tagID int) error
+}
+
+// MediaStore manages pronunciation clips and images along with their files
+type MediaStore interface {
+	CreateWordAudio(ctx context.Context, wordID int, r io.Reader) (*models.WordAudio, error)
+	GetWordAudio(ctx context.Context, wordID, audioID int) (*models.WordAudio, error)
+	DeleteWordAudio(ctx context.Context, wordID, audioID int) error
+	CreateWordImage(ctx context.Context, wordID int, data []byte) (*models.Image, bool, error)
+	GetImage(ctx context.Context, imageID int) (*models.Image, error)
+	DeleteWordImage(ctx context.Context, wordID, imageID int) error
+}
+
+// StatsStore reports study statistics and resets study data
+type StatsStore interface {
+	StudyProgress(ctx context.Context) (*models.StudyProgress, error)
+	QuickStats(ctx context.Context) (*models.QuickStats, error)
+	ResetHistory(ctx context.Context) error
+	FullReset(ctx context.Context, seed string) error
+}
+
+// TransferStore exports words to and imports them from export files
+type TransferStore interface {
+	ExportWords(ctx context.Context) ([]models.ExportedWord, error)
+	ImportWords(ctx context.Context, words []models.ExportedWord) (int, error)
+}
+
+// BackupStore copies the database to and from backup files
+type BackupStore interface {
+	Backup(ctx context.Context, path string) error
+	Restore(ctx context.Context, path string) error
+}
+
+// WordSorts lists the sort keys accepted by ListWords
+var WordSorts = repository.WordSorts
+
+// WordFilter selects and orders the words returned by ListWords. Zero
+// values leave the corresponding filter unset.
+type WordFilter struct {
+	Level        string
+	GroupID      int
+	CreatedSince time.Time
+	Tags         *tags.Query
+	MasteryMin   *float64
+	MasteryMax   *float64
+	Reviewed     *bool
+	Sort         string
+	Desc         bool
+}
+
+// SessionFilter narrows the study sessions returned by ListStudySessions
+// to a group or activity when the corresponding ID is set
+type SessionFilter struct {
+	GroupID    int
+	ActivityID int
+}
@@ -0,0 +1,303 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db/seeds"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/media"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/tags"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/testutil"
+)
+
+func TestSQLiteStore(t *testing.T) {
+	testStore(t, func(t *testing.T) *SQL {
+		return NewSQLite(testutil.NewTestDB(t), media.Dir(t.TempDir()))
+	})
+}
+
+func TestPostgresStore(t *testing.T) {
+	testStore(t, func(t *testing.T) *SQL {
+		return NewPostgres(testutil.NewPostgresTestDB(t), media.Dir(t.TempDir()))
+	})
+}
+
+// testStore runs the same checks against stores of every dialect, each
+// subtest on a freshly seeded database
+func testStore(t *testing.T, newStore func(t *testing.T) *SQL) {
+	ctx := context.Background()
+	page := pagination.Request{Limit: 50}
+
+	t.Run("words", func(t *testing.T) {
+		t.Parallel()
+		// Setup
+		s := newStore(t)
+
+		// Test sorting by mastery and last review
+		words, err := s.ListWords(ctx, WordFilter{Sort: "mastery", Desc: true}, page)
+		if err != nil {
+			t.Fatalf("Failed to list words: %v", err)
+		}
+		if len(words) != 3 || words[2].English != "goodbye" {
+			t.Errorf("Expected goodbye to have the lowest mastery, got %+v", words)
+		}
+		words, err = s.ListWords(ctx, WordFilter{Sort: "last_reviewed"}, page)
+		if err != nil {
+			t.Fatalf("Failed to list words by last review: %v", err)
+		}
+		if len(words) != 3 {
+			t.Errorf("Expected 3 words, got %d", len(words))
+		}
+
+		// Test filters
+		reviewed := true
+		min := 0.5
+		words, err = s.ListWords(ctx, WordFilter{GroupID: 1, Reviewed: &reviewed, MasteryMin: &min}, page)
+		if err != nil {
+			t.Fatalf("Failed to filter words: %v", err)
+		}
+		if len(words) != 1 || words[0].English != "hello" {
+			t.Errorf("Expected only hello, got %+v", words)
+		}
+		words, err = s.ListWords(ctx, WordFilter{CreatedSince: time.Now().Add(-time.Hour)}, page)
+		if err != nil {
+			t.Fatalf("Failed to filter words by creation time: %v", err)
+		}
+		if len(words) != 3 {
+			t.Errorf("Expected 3 recently created words, got %d", len(words))
+		}
+
+		// Test missing and deleted words
+		if _, err := s.GetWord(ctx, 999); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+		if err := s.DeleteWord(ctx, 3); err != nil {
+			t.Fatalf("Failed to delete word: %v", err)
+		}
+		if _, err := s.GetWord(ctx, 3); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected deleted word to be gone, got %v", err)
+		}
+	})
+
+	t.Run("sessions", func(t *testing.T) {
+		t.Parallel()
+		// Setup
+		s := newStore(t)
+
+		// Test creating a session makes it the latest
+		session, err := s.CreateStudySession(ctx, 1, time.Now().UTC().Truncate(time.Second))
+		if err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		if session.ID != 3 {
+			t.Errorf("Expected session ID 3, got %d", session.ID)
+		}
+		last, err := s.LastStudySession(ctx)
+		if err != nil {
+			t.Fatalf("Failed to get last session: %v", err)
+		}
+		if last.ID != session.ID {
+			t.Errorf("Expected last session %d, got %d", session.ID, last.ID)
+		}
+
+		// Test listing and session words
+		sessions, err := s.ListStudySessions(ctx, SessionFilter{ActivityID: 1}, page)
+		if err != nil {
+			t.Fatalf("Failed to list sessions: %v", err)
+		}
+		if len(sessions) != 2 || sessions[0].ID != session.ID {
+			t.Errorf("Expected the new session first, got %+v", sessions)
+		}
+		words, err := s.ListSessionWords(ctx, session, page)
+		if err != nil {
+			t.Fatalf("Failed to list session words: %v", err)
+		}
+		if len(words) != 2 || words[0].English != "goodbye" {
+			t.Errorf("Expected goodbye then hello, got %+v", words)
+		}
+
+		// Test recording a review
+		mastery, err := s.RecordReview(ctx, 2, 1, true, 1.0)
+		if err != nil {
+			t.Fatalf("Failed to record review: %v", err)
+		}
+		if mastery != 0.5 {
+			t.Errorf("Expected mastery 0.5, got %v", mastery)
+		}
+	})
+
+	t.Run("references", func(t *testing.T) {
+		t.Parallel()
+		// Setup
+		s := newStore(t)
+
+		// Test missing groups are reported in request order
+		missing, err := s.MissingGroups(ctx, []int{999, 1, 998})
+		if err != nil {
+			t.Fatalf("Failed to check groups: %v", err)
+		}
+		if len(missing) != 2 || missing[0] != 999 || missing[1] != 998 {
+			t.Errorf("Expected groups 999 and 998 to be missing, got %v", missing)
+		}
+
+		// Test finding an activity with its groups
+		activity, err := s.FindStudyActivity(ctx, 1)
+		if err != nil {
+			t.Fatalf("Failed to find activity: %v", err)
+		}
+		if len(activity.GroupIDs) == 0 {
+			t.Errorf("Expected activity 1 to have groups, got %+v", activity)
+		}
+		if _, err := s.FindStudyActivity(ctx, 999); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		t.Parallel()
+		// Setup
+		s := newStore(t)
+
+		// Test creating a duplicate tag
+		if _, err := s.CreateTag(ctx, "greeting"); err != nil {
+			t.Fatalf("Failed to create tag: %v", err)
+		}
+		if _, err := s.CreateTag(ctx, "greeting"); !errors.Is(err, ErrConflict) {
+			t.Errorf("Expected ErrConflict, got %v", err)
+		}
+
+		// Test only one of several concurrent requests gets a name
+		errs := make(chan error, 4)
+		for i := 0; i < cap(errs); i++ {
+			go func() {
+				_, err := s.CreateTag(ctx, "farewell")
+				errs <- err
+			}()
+		}
+		created := 0
+		for i := 0; i < cap(errs); i++ {
+			if err := <-errs; err == nil {
+				created++
+			} else if !errors.Is(err, ErrConflict) {
+				t.Errorf("Expected ErrConflict, got %v", err)
+			}
+		}
+		if created != 1 {
+			t.Errorf("Expected 1 tag to be created, got %d", created)
+		}
+		farewell, err := s.getTagID(ctx, "farewell")
+		if err != nil {
+			t.Fatalf("Failed to find tag: %v", err)
+		}
+		if _, err := s.UpdateTag(ctx, farewell, "greeting"); !errors.Is(err, ErrConflict) {
+			t.Errorf("Expected renaming onto a taken name to conflict, got %v", err)
+		}
+		if _, err := s.UpdateTag(ctx, farewell, "farewell"); err != nil {
+			t.Errorf("Expected a tag to keep its own name, got %v", err)
+		}
+
+		// Test tagging words twice and grouping them
+		for i := 0; i < 2; i++ {
+			if _, err := s.AddWordTags(ctx, 1, []string{"greeting", "common"}); err != nil {
+				t.Fatalf("Failed to tag word: %v", err)
+			}
+		}
+		query, err := tags.Parse("greeting")
+		if err != nil {
+			t.Fatalf("Failed to parse tag query: %v", err)
+		}
+		groupID, count, err := s.CreateGroupFromTags(ctx, "Greetings", query)
+		if err != nil {
+			t.Fatalf("Failed to create group from tags: %v", err)
+		}
+		if groupID != 3 || count != 1 {
+			t.Errorf("Expected group 3 with 1 word, got group %d with %d", groupID, count)
+		}
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		t.Parallel()
+		// Setup
+		s := newStore(t)
+
+		// Test importing merges into existing words and creates the rest
+		created, err := s.ImportWords(ctx, []models.ExportedWord{
+			{English: "hello", Spanish: "hola", Level: "beginner", Groups: []string{"Test Group 1", "Imported"}, Tags: []string{"greeting"}},
+			{English: "cat", Spanish: "gato", Level: "beginner", Groups: []string{"Imported"}},
+		})
+		if err != nil {
+			t.Fatalf("Failed to import words: %v", err)
+		}
+		if created != 1 {
+			t.Errorf("Expected 1 new word, got %d", created)
+		}
+		if _, err := s.ImportWords(ctx, []models.ExportedWord{{English: "dog"}}); err == nil {
+			t.Error("Expected a word without a translation to be rejected")
+		}
+
+		// Test the export includes the imported groups and tags
+		words, err := s.ExportWords(ctx)
+		if err != nil {
+			t.Fatalf("Failed to export words: %v", err)
+		}
+		if len(words) != 4 || words[3].English != "cat" {
+			t.Fatalf("Expected 4 words ending with cat, got %+v", words)
+		}
+		hello := words[0]
+		if len(hello.Groups) != 2 || hello.Groups[0] != "Imported" || len(hello.Tags) != 1 || hello.Tags[0] != "greeting" {
+			t.Errorf("Expected hello in 2 groups tagged greeting, got %+v", hello)
+		}
+		if len(words[3].Groups) != 1 || words[3].Groups[0] != "Imported" {
+			t.Errorf("Expected cat to join the Imported group, got %+v", words[3])
+		}
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		t.Parallel()
+		// Setup
+		s := newStore(t)
+
+		// Test IDs restart after a full reset
+		if err := s.FullReset(ctx, ""); err != nil {
+			t.Fatalf("Failed to reset: %v", err)
+		}
+		group, err := s.CreateGroup(ctx, "Fresh")
+		if err != nil {
+			t.Fatalf("Failed to create group: %v", err)
+		}
+		if group.ID != 1 {
+			t.Errorf("Expected group ID 1, got %d", group.ID)
+		}
+		activityID, err := s.CreateStudyActivity(ctx, "Fresh", "Fresh activity", []int{group.ID})
+		if err != nil {
+			t.Fatalf("Failed to create activity: %v", err)
+		}
+		activity, err := s.GetStudyActivity(ctx, activityID)
+		if err != nil {
+			t.Fatalf("Failed to get activity: %v", err)
+		}
+		if activity.ID != 1 || activity.Name != "Fresh" {
+			t.Errorf("Expected activity 1 named Fresh, got %+v", activity)
+		}
+
+		// Test reseeding in the same reset
+		seed, err := seeds.Load(seeds.Default)
+		if err != nil {
+			t.Fatalf("Failed to load seed pack: %v", err)
+		}
+		if err := s.FullReset(ctx, seed); err != nil {
+			t.Fatalf("Failed to reset with seed pack: %v", err)
+		}
+		seeded, err := s.GetGroup(ctx, 1)
+		if err != nil {
+			t.Fatalf("Failed to get seeded group: %v", err)
+		}
+		if seeded.Name != "Basic Greetings" || seeded.Statistics.TotalWordCount != 4 {
+			t.Errorf("Expected seeded Basic Greetings group, got %+v", seeded)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/metrics"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+)
+
+// ListTags returns tags with the number of words carrying each, ordered by name
+func (s *SQL) ListTags(ctx context.Context, page pagination.Request) ([]models.TagWithStats, error) {
+	defer metrics.ObserveQuery("ListTags", time.Now())
+	keyset, args := page.Keyset([]string{"t.name", "t.id"}, false)
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT
+			t.id,
+			t.name,
+			t.created_at,
+			t.updated_at,
+			COUNT(wt.word_id) as word_count
+		FROM tags t
+		LEFT JOIN word_tags wt ON t.id = wt.tag_id
+		WHERE `+keyset+`
+		GROUP BY t.id
+		ORDER BY t.name, t.id
+		LIMIT ?
+	`, append(args, page.Fetch())...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.TagWithStats
+	for rows.Next() {
+		var tag models.TagWithStats
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.CreatedAt, &tag.UpdatedAt, &tag.WordCount); err != nil {
+			return nil, err
+		}
+		result = append(result, tag)
+	}
+	return result, rows.Err()
+}
+
+// CreateTag creates a tag. It returns ErrConflict if the name is taken,
+// which the UNIQUE name decides so concurrent requests cannot both pass.
+func (s *SQL) CreateTag(ctx context.Context, name string) (*models.Tag, error) {
+	defer metrics.ObserveQuery("CreateTag", time.Now())
+	tagID, err := db.Insert(ctx, s.db, `
+		INSERT INTO tags (name, created_at, updated_at)
+		VALUES (?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, name)
+	if db.IsUniqueViolation(err) {
+		return nil, ErrConflict
+	} else if err != nil {
+		return nil, err
+	}
+
+	return s.getTag(ctx, int(tagID))
+}
+
+// UpdateTag renames a tag. It returns ErrConflict if another tag has the name.
+func (s *SQL) UpdateTag(ctx context.Context, tagID int, name string) (*models.Tag, error) {
+	defer metrics.ObserveQuery("UpdateTag", time.Now())
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE tags
+		SET name = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, name, tagID)
+	if db.IsUniqueViolation(err) {
+		return nil, ErrConflict
+	} else if err != nil {
+		return nil, err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return nil, ErrNotFound
+	}
+
+	return s.getTag(ctx, tagID)
+}
+
+// DeleteTag deletes a tag, which the foreign keys remove from every word
+func (s *SQL) DeleteTag(ctx context.Context, tagID int) error {
+	defer metrics.ObserveQuery("DeleteTag", time.Now())
+	result, err := s.db.ExecContext(ctx, "DELETE FROM tags WHERE id = ?", tagID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// AddWordTags tags a word, creating any tags that do not exist yet, and
+// returns the word's tags. It returns ErrNotFound if the word does not exist.
+func (s *SQL) AddWordTags(ctx context.Context, wordID int, names []string) ([]string, error) {
+	defer metrics.ObserveQuery("AddWordTags", time.Now())
+	exists, err := s.exists(ctx, "SELECT 1 FROM words WHERE id = ?", wordID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	err = s.withTx(ctx, func(tx *db.Tx) error {
+		for _, name := range names {
+			if _, err := tx.ExecContext(ctx, "INSERT INTO tags (name) VALUES (?) ON CONFLICT DO NOTHING", name); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO word_tags (word_id, tag_id)
+				SELECT ?, id FROM tags WHERE name = ?
+				ON CONFLICT DO NOTHING
+			`, wordID, name)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	words := []models.WordWithStats{{Word: models.Word{ID: wordID}}}
+	if err := s.attachWordTags(ctx, words); err != nil {
+		return nil, err
+	}
+	return words[0].Tags, nil
+}
+
+// DeleteWordTag removes a tag from a word
+func (s *SQL) DeleteWordTag(ctx context.Context, wordID, tagID int) error {
+	defer metrics.ObserveQuery("DeleteWordTag", time.Now())
+	result, err := s.db.ExecContext(ctx, "DELETE FROM word_tags WHERE word_id = ? AND tag_id = ?", wordID, tagID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// getTag returns a tag by ID
+func (s *SQL) getTag(ctx context.Context, tagID int) (*models.Tag, error) {
+	var tag models.Tag
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, name, created_at, updated_at
+		FROM tags
+		WHERE id = ?
+	`, tagID).Scan(&tag.ID, &tag.Name, &tag.CreatedAt, &tag.UpdatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &tag, nil
+}
+
+// getTagID returns the ID of the tag with the given name
+func (s *SQL) getTagID(ctx context.Context, name string) (int, error) {
+	var tagID int
+	err := s.db.QueryRowContext(ctx, "SELECT id FROM tags WHERE name = ?", name).Scan(&tagID)
+	return tagID, err
+}
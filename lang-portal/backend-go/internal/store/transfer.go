@@ -0,0 +1,148 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/metrics"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+)
+
+// ExportWords returns every word with the names of its groups and tags, in
+// ID order
+func (s *SQL) ExportWords(ctx context.Context) ([]models.ExportedWord, error) {
+	defer metrics.ObserveQuery("ExportWords", time.Now())
+	rows, err := s.db.QueryContext(ctx, "SELECT id, english, spanish, level FROM words ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	words := []models.ExportedWord{}
+	index := make(map[int]int)
+	for rows.Next() {
+		var wordID int
+		var word models.ExportedWord
+		if err := rows.Scan(&wordID, &word.English, &word.Spanish, &word.Level); err != nil {
+			return nil, err
+		}
+		index[wordID] = len(words)
+		words = append(words, word)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Attach group and tag names
+	links := []struct {
+		query string
+		add   func(word *models.ExportedWord, name string)
+	}{
+		{
+			"SELECT wg.word_id, g.name FROM word_groups wg JOIN groups g ON g.id = wg.group_id ORDER BY g.name",
+			func(word *models.ExportedWord, name string) { word.Groups = append(word.Groups, name) },
+		},
+		{
+			"SELECT wt.word_id, t.name FROM word_tags wt JOIN tags t ON t.id = wt.tag_id ORDER BY t.name",
+			func(word *models.ExportedWord, name string) { word.Tags = append(word.Tags, name) },
+		},
+	}
+	for _, link := range links {
+		if err := s.eachName(ctx, link.query, func(wordID int, name string) {
+			link.add(&words[index[wordID]], name)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return words, nil
+}
+
+// eachName calls fn for each word ID and name returned by query
+func (s *SQL) eachName(ctx context.Context, query string, fn func(wordID int, name string)) error {
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wordID int
+		var name string
+		if err := rows.Scan(&wordID, &name); err != nil {
+			return err
+		}
+		fn(wordID, name)
+	}
+	return rows.Err()
+}
+
+// ImportWords adds words along with their groups and tags in a single
+// transaction and returns how many words were new. Words already present
+// with the same English and Spanish are kept and only gain the imported
+// groups and tags. Groups and tags are matched by name and created if
+// missing.
+func (s *SQL) ImportWords(ctx context.Context, words []models.ExportedWord) (int, error) {
+	defer metrics.ObserveQuery("ImportWords", time.Now())
+	for i, word := range words {
+		if word.English == "" || word.Spanish == "" || word.Level == "" {
+			return 0, fmt.Errorf("word %d: english, spanish and level are required", i+1)
+		}
+	}
+
+	created := 0
+	err := s.withTx(ctx, func(tx *db.Tx) error {
+		groupIDs := make(map[string]int64)
+		for _, word := range words {
+			var wordID int64
+			err := tx.QueryRowContext(ctx, "SELECT id FROM words WHERE english = ? AND spanish = ?", word.English, word.Spanish).Scan(&wordID)
+			if err == sql.ErrNoRows {
+				wordID, err = db.Insert(ctx, tx, "INSERT INTO words (english, spanish, level) VALUES (?, ?, ?)", word.English, word.Spanish, word.Level)
+				if err == nil {
+					created++
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("failed to import word %q: %w", word.English, err)
+			}
+
+			for _, name := range word.Groups {
+				groupID, ok := groupIDs[name]
+				if !ok {
+					err := tx.QueryRowContext(ctx, "SELECT id FROM groups WHERE name = ? ORDER BY id LIMIT 1", name).Scan(&groupID)
+					if err == sql.ErrNoRows {
+						groupID, err = db.Insert(ctx, tx, "INSERT INTO groups (name) VALUES (?)", name)
+					}
+					if err != nil {
+						return fmt.Errorf("failed to import group %q: %w", name, err)
+					}
+					groupIDs[name] = groupID
+				}
+				if _, err := tx.ExecContext(ctx, "INSERT INTO word_groups (word_id, group_id) VALUES (?, ?) ON CONFLICT DO NOTHING", wordID, groupID); err != nil {
+					return err
+				}
+			}
+
+			for _, name := range word.Tags {
+				if _, err := tx.ExecContext(ctx, "INSERT INTO tags (name) VALUES (?) ON CONFLICT DO NOTHING", name); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx, `
+					INSERT INTO word_tags (word_id, tag_id)
+					SELECT ?, id FROM tags WHERE name = ?
+					ON CONFLICT DO NOTHING
+				`, wordID, name)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return created, nil
+}
@@ -0,0 +1,208 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/metrics"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/models"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/pagination"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/repository"
+)
+
+// ListWords returns words matching filter with their statistics, images and
+// tags. Each word's SortKey holds the value it was ordered by.
+func (s *SQL) ListWords(ctx context.Context, filter WordFilter, page pagination.Request) ([]models.WordWithStats, error) {
+	defer metrics.ObserveQuery("ListWords", time.Now())
+	query := repository.Words().SortBy(filter.Sort, filter.Desc).Page(page)
+	if filter.Level != "" {
+		query.Level(filter.Level)
+	}
+	if filter.GroupID != 0 {
+		query.InGroup(filter.GroupID)
+	}
+	if !filter.CreatedSince.IsZero() {
+		query.CreatedSince(filter.CreatedSince)
+	}
+	if filter.Tags != nil {
+		query.Tagged(*filter.Tags)
+	}
+	if filter.MasteryMin != nil {
+		query.MinMastery(*filter.MasteryMin)
+	}
+	if filter.MasteryMax != nil {
+		query.MaxMastery(*filter.MasteryMax)
+	}
+	if filter.Reviewed != nil {
+		query.Reviewed(*filter.Reviewed)
+	}
+
+	words, err := query.All(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachWordDetails(ctx, words); err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+// GetWord returns a word with its statistics, images, tags and pronunciation clips
+func (s *SQL) GetWord(ctx context.Context, wordID int) (*models.WordDetail, error) {
+	defer metrics.ObserveQuery("GetWord", time.Now())
+	withStats, err := repository.Words().ID(wordID).One(ctx, s.db)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	withDetails := []models.WordWithStats{*withStats}
+	if err := s.attachWordDetails(ctx, withDetails); err != nil {
+		return nil, err
+	}
+	word := models.WordDetail{WordWithStats: withDetails[0]}
+
+	word.Audio, err = s.listWordAudio(ctx, wordID)
+	if err != nil {
+		return nil, err
+	}
+	return &word, nil
+}
+
+// FindWord returns a word without statistics
+func (s *SQL) FindWord(ctx context.Context, wordID int) (*models.Word, error) {
+	defer metrics.ObserveQuery("FindWord", time.Now())
+	var word models.Word
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, english, spanish, level, created_at, updated_at
+		FROM words
+		WHERE id = ?
+	`, wordID).Scan(&word.ID, &word.English, &word.Spanish, &word.Level, &word.CreatedAt, &word.UpdatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &word, nil
+}
+
+// DeleteWord deletes a word along with its reviews, group links, tags, audio
+// clips and images, removing media files once the rows are gone
+func (s *SQL) DeleteWord(ctx context.Context, wordID int) error {
+	defer metrics.ObserveQuery("DeleteWord", time.Now())
+	// Collect audio files and images to remove once the rows are gone
+	audio, err := s.listWordAudioFiles(ctx, wordID)
+	if err != nil {
+		return err
+	}
+	imageIDs, err := s.listWordImageIDs(ctx, wordID)
+	if err != nil {
+		return err
+	}
+
+	// Dependent rows are deleted by the foreign keys' ON DELETE CASCADE
+	result, err := s.db.ExecContext(ctx, "DELETE FROM words WHERE id = ?", wordID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNotFound
+	}
+
+	for _, filename := range audio {
+		if err := s.media.Remove(filename); err != nil {
+			slog.ErrorContext(ctx, "Failed to remove audio file", "file", filename, "err", err)
+		}
+	}
+	if err := s.removeOrphanImages(ctx, imageIDs); err != nil {
+		slog.ErrorContext(ctx, "Failed to remove orphaned images", "err", err)
+	}
+	return nil
+}
+
+// attachWordDetails loads the images and tags of each word
+func (s *SQL) attachWordDetails(ctx context.Context, words []models.WordWithStats) error {
+	if err := s.attachWordImages(ctx, words); err != nil {
+		return fmt.Errorf("failed to fetch word images: %w", err)
+	}
+	if err := s.attachWordTags(ctx, words); err != nil {
+		return fmt.Errorf("failed to fetch word tags: %w", err)
+	}
+	return nil
+}
+
+// attachWordImages loads the images linked to each word
+func (s *SQL) attachWordImages(ctx context.Context, words []models.WordWithStats) error {
+	if len(words) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(words))
+	index := make(map[int]int, len(words))
+	for i := range words {
+		ids[i] = words[i].ID
+		index[words[i].ID] = i
+		words[i].Images = []models.WordImage{}
+	}
+	placeholders, args := inList(ids)
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT word_id, image_id
+		FROM word_images
+		WHERE word_id IN (`+placeholders+`)
+		ORDER BY id
+	`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wordID, imageID int
+		if err := rows.Scan(&wordID, &imageID); err != nil {
+			return err
+		}
+		image := models.WordImage{ID: imageID}
+		image.URL, image.ThumbnailURL = models.ImageURLs(imageID)
+		words[index[wordID]].Images = append(words[index[wordID]].Images, image)
+	}
+	return rows.Err()
+}
+
+// attachWordTags loads the tag names on each word
+func (s *SQL) attachWordTags(ctx context.Context, words []models.WordWithStats) error {
+	if len(words) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(words))
+	index := make(map[int]int, len(words))
+	for i := range words {
+		ids[i] = words[i].ID
+		index[words[i].ID] = i
+		words[i].Tags = []string{}
+	}
+	placeholders, args := inList(ids)
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT wt.word_id, t.name
+		FROM word_tags wt
+		JOIN tags t ON t.id = wt.tag_id
+		WHERE wt.word_id IN (`+placeholders+`)
+		ORDER BY t.name
+	`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wordID int
+		var name string
+		if err := rows.Scan(&wordID, &name); err != nil {
+			return err
+		}
+		words[index[wordID]].Tags = append(words[index[wordID]].Tags, name)
+	}
+	return rows.Err()
+}
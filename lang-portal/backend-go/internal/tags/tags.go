@@ -0,0 +1,118 @@
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MaxNameLength is the longest tag name accepted
+const MaxNameLength = 50
+
+var (
+	// ErrInvalidName is returned for empty, over-long or malformed tag names
+	ErrInvalidName = errors.New("invalid tag name")
+	// ErrInvalidQuery is returned when a tag query cannot be parsed
+	ErrInvalidQuery = errors.New("invalid tag query")
+)
+
+// Normalize lowercases a tag name and joins words with hyphens, so that
+// "False Friend" and "false-friend" refer to the same tag. Names may contain
+// letters, digits, hyphens and underscores and must start with a letter or digit.
+func Normalize(name string) (string, error) {
+	name = strings.Join(strings.Fields(strings.ToLower(name)), "-")
+	if name == "" || len([]rune(name)) > MaxNameLength {
+		return "", ErrInvalidName
+	}
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+		case (r == '-' || r == '_') && i > 0:
+		default:
+			return "", ErrInvalidName
+		}
+	}
+	return name, nil
+}
+
+// Clause matches words carrying any of its tags, or none of them when negated
+type Clause struct {
+	Negate bool
+	Any    []string
+}
+
+// Query is a conjunction of clauses; a word matches when every clause does
+type Query []Clause
+
+// Parse reads a tag query such as "verb,-irregular" or "noun|verb,-exam-2025".
+// Commas separate terms that must all match, a leading "-" negates a term and
+// "|" lists alternatives within a term.
+func Parse(expr string) (Query, error) {
+	var query Query
+	for _, term := range strings.Split(expr, ",") {
+		term = strings.TrimSpace(term)
+
+		var clause Clause
+		if strings.HasPrefix(term, "-") {
+			clause.Negate = true
+			term = term[1:]
+		}
+
+		for _, name := range strings.Split(term, "|") {
+			normalized, err := Normalize(name)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q is not a valid tag", ErrInvalidQuery, strings.TrimSpace(name))
+			}
+			clause.Any = append(clause.Any, normalized)
+		}
+		query = append(query, clause)
+	}
+	return query, nil
+}
+
+// String returns the canonical form of the query
+func (q Query) String() string {
+	terms := make([]string, len(q))
+	for i, clause := range q {
+		terms[i] = strings.Join(clause.Any, "|")
+		if clause.Negate {
+			terms[i] = "-" + terms[i]
+		}
+	}
+	return strings.Join(terms, ",")
+}
+
+// Names returns every tag name referenced by the query
+func (q Query) Names() []string {
+	var names []string
+	for _, clause := range q {
+		names = append(names, clause.Any...)
+	}
+	return names
+}
+
+// SQL returns a WHERE condition, and its arguments, matching the query against
+// the word ID in wordColumn
+func (q Query) SQL(wordColumn string) (string, []interface{}) {
+	conditions := make([]string, len(q))
+	var args []interface{}
+	for i, clause := range q {
+		placeholders := make([]string, len(clause.Any))
+		for j, name := range clause.Any {
+			placeholders[j] = "?"
+			args = append(args, name)
+		}
+
+		condition := fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM word_tags wt
+			JOIN tags t ON t.id = wt.tag_id
+			WHERE wt.word_id = %s AND t.name IN (%s)
+		)`, wordColumn, strings.Join(placeholders, ", "))
+		if clause.Negate {
+			condition = "NOT " + condition
+		}
+		conditions[i] = condition
+	}
+	return strings.Join(conditions, " AND "), args
+}
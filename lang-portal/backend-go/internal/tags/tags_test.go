@@ -0,0 +1,80 @@
+package tags
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"verb", "verb"},
+		{"  Irregular ", "irregular"},
+		{"False Friend", "false-friend"},
+		{"exam-2025", "exam-2025"},
+		{"día_festivo", "día_festivo"},
+	}
+
+	for _, tt := range tests {
+		got, err := Normalize(tt.name)
+		if err != nil {
+			t.Errorf("Normalize(%q) returned error: %v", tt.name, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("Normalize(%q): expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+
+	for _, name := range []string{"", "   ", "-verb", "verb!", "a,b", "a|b"} {
+		if _, err := Normalize(name); err != ErrInvalidName {
+			t.Errorf("Normalize(%q): expected ErrInvalidName, got %v", name, err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	query, err := Parse("Verb, -irregular,noun|adjective")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	if len(query) != 3 {
+		t.Fatalf("Expected 3 clauses, got %d", len(query))
+	}
+	if query[0].Negate || len(query[0].Any) != 1 || query[0].Any[0] != "verb" {
+		t.Errorf("Unexpected first clause: %+v", query[0])
+	}
+	if !query[1].Negate || query[1].Any[0] != "irregular" {
+		t.Errorf("Unexpected second clause: %+v", query[1])
+	}
+	if len(query[2].Any) != 2 || query[2].Any[1] != "adjective" {
+		t.Errorf("Unexpected third clause: %+v", query[2])
+	}
+	if got := query.String(); got != "verb,-irregular,noun|adjective" {
+		t.Errorf("Expected canonical query, got %q", got)
+	}
+
+	for _, expr := range []string{"", "verb,", "-", "verb|", "verb,-"} {
+		if _, err := Parse(expr); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("Parse(%q): expected ErrInvalidQuery, got %v", expr, err)
+		}
+	}
+}
+
+func TestSQL(t *testing.T) {
+	query, err := Parse("verb|noun,-irregular")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	condition, args := query.SQL("w.id")
+	if len(args) != 3 || args[0] != "verb" || args[1] != "noun" || args[2] != "irregular" {
+		t.Errorf("Unexpected arguments: %v", args)
+	}
+	if condition == "" {
+		t.Error("Expected a condition")
+	}
+}
@@ -1,54 +1,163 @@
 package testutil
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/db/migrations"
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/problem"
 	"github.com/gin-gonic/gin"
 )
 
-// SetupTestDB creates a test database and returns a cleanup function
-func SetupTestDB(t *testing.T) func() {
+// NewTestDB creates a migrated and seeded test database that is closed when the test ends
+func NewTestDB(t *testing.T) *sql.DB {
 	t.Helper()
 
 	// Create a temporary database file
-	tmpDir := t.TempDir()
-	dbPath := filepath.Join(tmpDir, "test.db")
+	conn, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize test database: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	// Run migrations
+	if err := runMigrations(conn); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	// Run test data seeding
+	if err := seedTestData(conn); err != nil {
+		t.Fatalf("Failed to seed test data: %v", err)
+	}
+
+	return conn
+}
+
+// NewTestConn creates a migrated and seeded test database file and connects
+// to it the way the server does. The connection is closed when the test ends.
+func NewTestConn(t *testing.T, opts db.Options) *db.Conn {
+	t.Helper()
+
+	// Create the database with a plain connection
+	path := filepath.Join(t.TempDir(), "test.db")
+	setup, err := db.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to initialize test database: %v", err)
+	}
+	defer setup.Close()
+	if err := runMigrations(setup); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+	if err := seedTestData(setup); err != nil {
+		t.Fatalf("Failed to seed test data: %v", err)
+	}
+
+	conn, err := db.Connect(path, opts)
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	return conn
+}
 
-	// Initialize the test database
-	if err := db.InitDB(dbPath); err != nil {
+// PostgresDSNEnv names the environment variable holding the PostgreSQL
+// server tests run against; Postgres tests are skipped when it is unset
+const PostgresDSNEnv = "LANGPORTAL_TEST_POSTGRES_DSN"
+
+// NewPostgresTestDB creates a migrated and seeded test database in a schema of
+// its own on the PostgreSQL server named by PostgresDSNEnv. The schema is
+// dropped when the test ends.
+func NewPostgresTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv(PostgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s not set", PostgresDSNEnv)
+	}
+
+	// Create a schema for this test
+	admin, err := db.Open(dsn)
+	if err != nil {
+		t.Fatalf("Failed to connect to PostgreSQL: %v", err)
+	}
+	defer admin.Close()
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if admin, err := db.Open(dsn); err == nil {
+			admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+			admin.Close()
+		}
+	})
+
+	// Connect with the schema first on the search path
+	conn, err := db.Open(withSearchPath(dsn, schema))
+	if err != nil {
 		t.Fatalf("Failed to initialize test database: %v", err)
 	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
 
 	// Run migrations
-	if err := runMigrations(db.GetDB()); err != nil {
+	if err := db.RunMigrations(conn, db.Postgres, migrations.FS); err != nil {
 		t.Fatalf("Failed to run migrations: %v", err)
 	}
 
 	// Run test data seeding
-	if err := seedTestData(db.GetDB()); err != nil {
+	if err := seedTestData(conn); err != nil {
 		t.Fatalf("Failed to seed test data: %v", err)
 	}
 
-	// Return cleanup function
-	return func() {
-		if err := db.CloseDB(); err != nil {
-			fmt.Printf("Error closing DB: %v\n", err)
+	// The seed data sets IDs explicitly, so move the sequences past them
+	for _, table := range []string{"groups", "words", "study_activities", "study_sessions", "word_review_items"} {
+		_, err := conn.Exec(fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM %s", table, table))
+		if err != nil {
+			t.Fatalf("Failed to reset %s sequence: %v", table, err)
 		}
-		os.Remove(dbPath)
 	}
+
+	return conn
+}
+
+// withSearchPath adds a search_path setting to a postgres:// URL
+func withSearchPath(dsn, schema string) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return dsn
+	}
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+	return u.String()
 }
 
+// setModeOnce switches Gin to test mode once, so parallel tests do not race on it
+var setModeOnce sync.Once
+
 // SetupTestRouter creates a test Gin router
 func SetupTestRouter() *gin.Engine {
-	gin.SetMode(gin.TestMode)
+	setModeOnce.Do(func() {
+		gin.SetMode(gin.TestMode)
+	})
 	return gin.New()
 }
 
@@ -61,6 +170,30 @@ func MakeRequest(r *gin.Engine, method, path string, body io.Reader) *httptest.R
 	return w
 }
 
+// MakeMultipartRequest performs a test HTTP request uploading content as a multipart file field
+func MakeMultipartRequest(t *testing.T, r *gin.Engine, method, path, field, filename string, content []byte) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatalf("Failed to create form file: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("Failed to write form file: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close multipart writer: %v", err)
+	}
+
+	req := httptest.NewRequest(method, path, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
 // ParseResponse parses the JSON response into the given struct
 func ParseResponse(t *testing.T, w *httptest.ResponseRecorder, v interface{}) {
 	t.Helper()
@@ -69,6 +202,20 @@ func ParseResponse(t *testing.T, w *httptest.ResponseRecorder, v interface{}) {
 	}
 }
 
+// ParseItems parses the items of a paginated list response into the given slice
+func ParseItems(t *testing.T, w *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	var page struct {
+		Items json.RawMessage `json:"items"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&page); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if err := json.Unmarshal(page.Items, v); err != nil {
+		t.Fatalf("Failed to parse response items: %v", err)
+	}
+}
+
 // AssertStatus checks if the response status code matches the expected code
 func AssertStatus(t *testing.T, w *httptest.ResponseRecorder, expected int) {
 	t.Helper()
@@ -77,6 +224,26 @@ func AssertStatus(t *testing.T, w *httptest.ResponseRecorder, expected int) {
 	}
 }
 
+// AssertProblem checks that the response is a problem with the expected
+// code and returns it
+func AssertProblem(t *testing.T, w *httptest.ResponseRecorder, code problem.Code) *problem.Problem {
+	t.Helper()
+	if got := w.Header().Get("Content-Type"); got != problem.ContentType {
+		t.Errorf("Expected content type %s, got %s", problem.ContentType, got)
+	}
+	var p problem.Problem
+	if err := json.NewDecoder(w.Body).Decode(&p); err != nil {
+		t.Fatalf("Failed to parse problem: %v", err)
+	}
+	if p.Code != code {
+		t.Errorf("Expected problem %s, got %s (%s)", code, p.Code, p.Detail)
+	}
+	if p.Status != w.Code {
+		t.Errorf("Problem status %d does not match response status %d", p.Status, w.Code)
+	}
+	return &p
+}
+
 // AssertJSON checks if the response body matches the expected JSON
 func AssertJSON(t *testing.T, w *httptest.ResponseRecorder, expected interface{}) {
 	t.Helper()
@@ -101,16 +268,9 @@ func AssertJSON(t *testing.T, w *httptest.ResponseRecorder, expected interface{}
 	}
 }
 
-// runMigrations runs the database migrations
-func runMigrations(db *sql.DB) error {
-	// Read and execute the migration SQL
-	migrationSQL, err := os.ReadFile("../db/migrations/001_create_tables.up.sql")
-	if err != nil {
-		return err
-	}
-
-	_, err = db.Exec(string(migrationSQL))
-	return err
+// runMigrations runs the embedded SQLite migrations
+func runMigrations(conn *sql.DB) error {
+	return db.RunMigrations(conn, db.SQLite, migrations.FS)
 }
 
 // seedTestData seeds the test database with sample data
@@ -152,9 +312,9 @@ func seedTestData(db *sql.DB) error {
 
 		-- Insert test word review items
 		INSERT INTO word_review_items (word_id, study_activity_id, correct, response_time) VALUES
-		(1, 1, 1, 1.5),
-		(2, 1, 0, 2.0),
-		(3, 1, 1, 1.0);
+		(1, 1, TRUE, 1.5),
+		(2, 1, FALSE, 2.0),
+		(3, 1, TRUE, 1.0);
 	`)
 	return err
 }
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apeabody/free-genai-bootcamp-2025/lang-portal/backend-go/internal/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+func TestSetup(t *testing.T) {
+	// Test tracing is off unless an exporter is chosen
+	shutdown, err := Setup(context.Background(), ExporterNone, "", nil)
+	if err != nil || shutdown(context.Background()) != nil {
+		t.Errorf("Expected no-op tracing, got %v", err)
+	}
+
+	// Test unknown exporters are rejected
+	if _, err := Setup(context.Background(), "jaeger", "", nil); err == nil || !strings.Contains(err.Error(), "jaeger") {
+		t.Errorf("Expected an unknown exporter error, got %v", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	// Setup spans exported to a buffer
+	var buf bytes.Buffer
+	shutdown, err := Setup(context.Background(), ExporterStdout, "", &buf)
+	if err != nil {
+		t.Fatalf("Failed to setup tracing: %v", err)
+	}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(requestid.Middleware())
+	r.Use(Middleware())
+	r.GET("/words/:id", func(c *gin.Context) {
+		c.Status(http.StatusInternalServerError)
+	})
+
+	// Test the span continues the caller's trace and is named after the route
+	req := httptest.NewRequest(http.MethodGet, "/words/7", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set(requestid.Header, "client-123")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("Failed to flush spans: %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{
+		`"Name":"GET /words/:id"`,
+		`"TraceID":"4bf92f3577b34da6a3ce929d0e0e4736"`,
+		`"SpanID":"00f067aa0ba902b7"`,
+		`"Value":"client-123"`,
+		`"Code":"Error"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected the exported span to include %s, got %s", want, got)
+		}
+	}
+}